@@ -0,0 +1,272 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+
+	"gorm.io/gorm"
+
+	"restaurant-api/internal/config"
+	"restaurant-api/internal/kakao"
+	"restaurant-api/internal/store"
+)
+
+// runCommand: 서버 대신 유지보수 명령을 실행하고 종료 코드를 반환
+func runCommand(cfg config.Config, args []string) int {
+	switch args[0] {
+	case "recalc-ratings":
+		return cmdRecalcRatings(cfg)
+	case "revoke-sessions":
+		return cmdRevokeSessions(cfg, args[1:])
+	case "set-role":
+		return cmdSetRole(cfg, args[1:])
+	case "seed":
+		return cmdSeed(cfg, args[1:])
+	case "data-quality":
+		return cmdDataQuality(cfg)
+	case "claim-ratings":
+		return cmdClaimRatings(cfg, args[1:])
+	case "fake-kakao":
+		return cmdFakeKakao(cfg, args[1:])
+	default:
+		fmt.Fprintf(os.Stderr, "알 수 없는 명령: %s\n", args[0])
+		fmt.Fprintln(os.Stderr, "사용법: go run . [recalc-ratings | revoke-sessions <user_id> | set-role <user_id> <user|admin> | claim-ratings <nickname> <user_id> | seed [-dry-run] [-prune] | data-quality | fake-kakao [-addr host:port]]")
+		return 2
+	}
+}
+
+// cmdRecalcRatings: ratings 테이블 기준으로 모든 식당의 평균 별점/참여 인원 재계산
+func cmdRecalcRatings(cfg config.Config) int {
+	db := openDB(cfg)
+	if db == nil {
+		return 1
+	}
+	n, err := store.RecalcAllRatings(db)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "별점 재계산 실패:", err)
+		return 1
+	}
+	fmt.Printf("식당 %d곳의 별점을 재계산했습니다.\n", n)
+	return 0
+}
+
+// cmdRevokeSessions: 사용자의 모든 서버 측 세션을 삭제해 강제 로그아웃
+func cmdRevokeSessions(cfg config.Config, args []string) int {
+	if len(args) != 1 {
+		fmt.Fprintln(os.Stderr, "사용법: go run . revoke-sessions <user_id>")
+		return 2
+	}
+	userID, err := strconv.ParseUint(args[0], 10, 64)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "user_id는 숫자여야 합니다:", args[0])
+		return 2
+	}
+
+	db := openDB(cfg)
+	if db == nil {
+		return 1
+	}
+	n, err := store.RevokeUserSessions(db, uint(userID))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "세션 삭제 실패:", err)
+		return 1
+	}
+	fmt.Printf("사용자 %d의 세션 %d개를 삭제했습니다.\n", userID, n)
+	return 0
+}
+
+// cmdSetRole: 사용자 역할 변경 (user 또는 admin)
+func cmdSetRole(cfg config.Config, args []string) int {
+	if len(args) != 2 || (args[1] != "user" && args[1] != store.RoleAdmin) {
+		fmt.Fprintln(os.Stderr, "사용법: go run . set-role <user_id> <user|admin>")
+		return 2
+	}
+	userID, err := strconv.ParseUint(args[0], 10, 64)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "user_id는 숫자여야 합니다:", args[0])
+		return 2
+	}
+
+	db := openDB(cfg)
+	if db == nil {
+		return 1
+	}
+	result := db.Model(&store.User{}).Where("id = ?", userID).Update("role", args[1])
+	if result.Error != nil {
+		fmt.Fprintln(os.Stderr, "역할 변경 실패:", result.Error)
+		return 1
+	}
+	if result.RowsAffected == 0 {
+		fmt.Fprintln(os.Stderr, "사용자를 찾을 수 없습니다:", userID)
+		return 1
+	}
+	fmt.Printf("사용자 %d의 역할을 %s(으)로 변경했습니다.\n", userID, args[1])
+	return 0
+}
+
+// cmdClaimRatings: 닉네임으로 남겨진 이전 별점을 본인 확인을 마친 사용자에게 연결
+func cmdClaimRatings(cfg config.Config, args []string) int {
+	if len(args) != 2 || args[0] == "" {
+		fmt.Fprintln(os.Stderr, "사용법: go run . claim-ratings <nickname> <user_id>")
+		return 2
+	}
+	userID, err := strconv.ParseUint(args[1], 10, 64)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "user_id는 숫자여야 합니다:", args[1])
+		return 2
+	}
+
+	db := openDB(cfg)
+	if db == nil {
+		return 1
+	}
+	claimed, skipped, err := store.ClaimLegacyRatings(db, args[0], uint(userID))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "이전 별점 연결 실패:", err)
+		return 1
+	}
+	fmt.Printf("%q의 이전 별점 %d건을 사용자 %d에게 연결했습니다.", args[0], claimed, userID)
+	if skipped > 0 {
+		fmt.Printf(" (이미 별점이 있는 식당 %d건은 그대로 둠)", skipped)
+	}
+	fmt.Println()
+	return 0
+}
+
+// cmdSeed: 내장 시드 데이터를 버전과 관계없이 적용 (-dry-run: 변경 내용만 출력, -prune: 시드에 없는 식당 삭제)
+func cmdSeed(cfg config.Config, args []string) int {
+	fs := flag.NewFlagSet("seed", flag.ContinueOnError)
+	dryRun := fs.Bool("dry-run", false, "변경 내용만 출력하고 적용하지 않음")
+	prune := fs.Bool("prune", false, "시드에 없는 식당을 삭제")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	db := openDB(cfg)
+	if db == nil {
+		return 1
+	}
+	file, err := store.LoadSeedFile()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	plan, err := store.PlanSeed(db, file)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "시드 비교 실패:", err)
+		return 1
+	}
+	printSeedPlan(plan, *prune)
+	if *dryRun {
+		return 0
+	}
+
+	if err := store.ApplySeed(db, plan, *prune); err != nil {
+		fmt.Fprintln(os.Stderr, "시드 적용 실패:", err)
+		return 1
+	}
+	if err := store.RecordSeedVersion(db, file.Version); err != nil {
+		fmt.Fprintln(os.Stderr, "시드 버전 기록 실패:", err)
+		return 1
+	}
+	fmt.Printf("시드 v%d 적용을 완료했습니다.\n", file.Version)
+	return 0
+}
+
+// cmdDataQuality: 식당 데이터 점검 결과 출력 (문제가 있으면 종료 코드 1)
+func cmdDataQuality(cfg config.Config) int {
+	db := openDB(cfg)
+	if db == nil {
+		return 1
+	}
+	report, err := store.DataQualityReport(db)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "데이터 점검 실패:", err)
+		return 1
+	}
+	printQualityReport(report)
+	if !report.Empty() {
+		return 1
+	}
+	return 0
+}
+
+// cmdFakeKakao: 가짜 카카오 인증/API 서버만 따로 실행 (KAKAO_FAKE_USERS로 테스트 사용자 지정)
+func cmdFakeKakao(cfg config.Config, args []string) int {
+	fs := flag.NewFlagSet("fake-kakao", flag.ContinueOnError)
+	addr := fs.String("addr", "127.0.0.1:9090", "가짜 카카오 서버 주소")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	users, err := kakao.ParseFakeUsers(cfg.KakaoFakeUsers)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "KAKAO_FAKE_USERS 설정 오류:", err)
+		return 2
+	}
+
+	fmt.Printf("가짜 카카오 서버: http://%s\n", *addr)
+	fmt.Printf("서버를 KAKAO_AUTH_URL=http://%[1]s KAKAO_API_URL=http://%[1]s 로 실행하세요.\n", *addr)
+	for _, u := range users {
+		fmt.Printf("  테스트 사용자 %d: %s\n", u.ID, u.Nickname)
+	}
+	if err := http.ListenAndServe(*addr, kakao.NewFakeServer(users)); err != nil {
+		fmt.Fprintln(os.Stderr, "가짜 카카오 서버 실행 실패:", err)
+		return 1
+	}
+	return 0
+}
+
+// openDB: 설정의 DB를 열고 마이그레이션 (실패하면 오류를 출력하고 nil)
+func openDB(cfg config.Config) *gorm.DB {
+	db, err := store.Open(cfg.DBPath)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "DB 연결 실패:", err)
+		return nil
+	}
+	return db
+}
+
+// printSeedPlan: dry-run 출력
+func printSeedPlan(plan store.SeedPlan, prune bool) {
+	for _, w := range plan.Warnings {
+		fmt.Println("! 경고:", w)
+	}
+	for _, seed := range plan.Added {
+		fmt.Printf("+ 추가: %s (%s)\n", seed.Title, seed.PlaceID)
+	}
+	for _, change := range plan.Changed {
+		fmt.Printf("~ 변경: [%d] %s → %s %v\n", change.Restaurant.ID, change.Restaurant.Title, change.Seed.Title, change.Fields)
+	}
+	removeLabel := "- 삭제 (--prune 시):"
+	if prune {
+		removeLabel = "- 삭제:"
+	}
+	for _, res := range plan.Removed {
+		fmt.Printf("%s [%d] %s\n", removeLabel, res.ID, res.Title)
+	}
+	fmt.Printf("추가 %d, 변경 %d, 삭제 대상 %d\n", len(plan.Added), len(plan.Changed), len(plan.Removed))
+}
+
+// printQualityReport: data-quality 명령 출력
+func printQualityReport(report store.QualityReport) {
+	sections := []struct {
+		label  string
+		issues []store.QualityIssue
+	}{
+		{"같은 장소 ID", report.SharedPlaceIDs},
+		{"같은 좌표, 다른 주소", report.SameCoordinates},
+		{"비슷한 이름", report.SimilarTitles},
+	}
+	for _, s := range sections {
+		fmt.Printf("[%s] %d건\n", s.label, len(s.issues))
+		for _, issue := range s.issues {
+			fmt.Printf("  %s\n", issue.Key)
+			for _, e := range issue.Restaurants {
+				fmt.Printf("    [%d] %s | %s | %s\n", e.ID, e.Title, e.Addr, e.URL)
+			}
+		}
+	}
+}
@@ -16,14 +16,37 @@ function initMap() {
 }
 
 async function fetchData(category = 'all', search = '') {
-    const url = `/api/restaurants?category=${category}&search=${search}`;
+    // 한 번에 최대 100곳씩 받으므로 next_cursor가 없을 때까지 이어서 받음
+    const params = new URLSearchParams({ category, search, limit: 100 });
+    const restaurants = [];
     try {
-        const response = await fetch(url);
-        const data = await response.json();
-        renderList(data);
+        while (true) {
+            const response = await fetch(`/api/restaurants?${params}`);
+            const data = await response.json();
+            restaurants.push(...data.restaurants);
+            if (!data.next_cursor) break;
+            params.set('cursor', data.next_cursor);
+        }
+        renderList(restaurants);
+        focusFromQuery(restaurants);
     } catch (e) { console.error("로드 실패", e); }
 }
 
+// 로그인 후 ?restaurant=ID 로 돌아왔을 때 해당 식당으로 이동 (최초 1회)
+function focusFromQuery(data) {
+    const params = new URLSearchParams(location.search);
+    const id = Number(params.get('restaurant'));
+    if (!id) return;
+    history.replaceState(null, '', location.pathname);
+
+    const item = data.find(d => d.ID === id);
+    const card = document.querySelector(`.res-card[data-id="${id}"]`);
+    if (item && card) {
+        card.scrollIntoView({ behavior: 'smooth', block: 'center' });
+        focusOn(item, card);
+    }
+}
+
 function renderList(data) {
     const container = document.getElementById('res-list');
     document.getElementById('list-count').textContent = `주변 맛집 ${data.length}곳`;
@@ -32,6 +55,7 @@ function renderList(data) {
     data.forEach(item => {
         const card = document.createElement('div');
         card.className = 'res-card';
+        card.dataset.id = item.ID;
         
         const avg = item.avg_rating || 0;
         const count = item.rating_count || 0;
@@ -61,7 +85,10 @@ async function submitRate(resId, score, event) {
     event.stopPropagation();
     // 전역 변수 IS_LOGGED_IN 사용 (index.html에서 넘어옴)
     if (typeof IS_LOGGED_IN === 'undefined' || !IS_LOGGED_IN) {
-        alert("별점을 남기려면 카카오 로그인이 필요합니다! 🔒");
+        if (confirm("별점을 남기려면 카카오 로그인이 필요합니다! 🔒\n로그인하시겠습니까?")) {
+            // 로그인 후 평가하던 식당으로 돌아오기
+            location.href = `/login/kakao?next=${encodeURIComponent('/?restaurant=' + resId)}`;
+        }
         return;
     }
 
@@ -122,9 +149,25 @@ function applySearch() {
     fetchData(category === 'all' ? 'all' : category, document.getElementById('search-input').value);
 }
 
+let lastPickId = null;
+
 async function pickRandom() {
-    const res = await fetch('/api/restaurants/random');
+    // 현재 카테고리/검색 조건 안에서 직전 추천을 제외하고 별점 가중치로 추천
+    const activeBtn = document.querySelector('.cat-btn.active');
+    const params = new URLSearchParams({
+        category: activeBtn ? activeBtn.dataset.category : 'all',
+        search: document.getElementById('search-input').value,
+        weight: 'rating'
+    });
+    if (lastPickId) params.append('exclude', lastPickId);
+
+    const res = await fetch(`/api/restaurants/random?${params}`);
     const pick = await res.json();
+    if (!res.ok) {
+        alert(pick.error);
+        return;
+    }
+    lastPickId = pick.ID;
     const cards = document.querySelectorAll('.res-card');
     cards.forEach(card => {
         if(card.querySelector('.res-title').textContent === pick.title) {
@@ -188,6 +231,32 @@ function initBottomSheet() {
     });
 }
 
+// 검색어 자동완성 (입력이 잠시 멈추면 조회)
+let suggestTimer = null;
+function loadSuggestions(q) {
+    clearTimeout(suggestTimer);
+    suggestTimer = setTimeout(async () => {
+        const datalist = document.getElementById('search-suggestions');
+        if (!q.trim()) {
+            datalist.innerHTML = '';
+            return;
+        }
+        try {
+            const res = await fetch(`/api/search/suggest?q=${encodeURIComponent(q)}`);
+            if (!res.ok) return;
+            const data = await res.json();
+            datalist.innerHTML = '';
+            data.suggestions.forEach(s => {
+                const option = document.createElement('option');
+                option.value = s.text;
+                datalist.appendChild(option);
+            });
+        } catch (err) {
+            console.error('자동완성 실패:', err);
+        }
+    }, 200);
+}
+
 // 초기화 및 이벤트 리스너 등록
 document.addEventListener('DOMContentLoaded', () => {
     initMap();
@@ -198,6 +267,7 @@ document.addEventListener('DOMContentLoaded', () => {
     document.getElementById('search-input').addEventListener('keyup', (e) => {
         if (e.key === 'Enter') applySearch();
     });
+    document.getElementById('search-input').addEventListener('input', (e) => loadSuggestions(e.target.value));
 
     // 랜덤 버튼
     document.getElementById('btn-random').addEventListener('click', pickRandom);
@@ -0,0 +1,106 @@
+// Package config: 환경변수(.env 포함)에서 읽는 서버 설정
+package config
+
+import (
+	"os"
+	"strconv"
+	"strings"
+)
+
+const (
+	DefaultSessionMaxAge = 7 * 24 * 60 * 60 // 7일 (초). SessionMaxAge가 0이면 이 값을 쓴다
+	defaultDBPath        = "restaurants.db"
+	defaultAssetDir      = "."
+)
+
+// Config: 서버 설정. 테스트에서는 필요한 값만 채워 직접 만들어 쓴다
+type Config struct {
+	Host      string // APP_HOST (기본 0.0.0.0)
+	Port      string // APP_PORT (기본 8080)
+	AppDomain string // APP_DOMAIN: 카카오 리다이렉트 주소 기준 (예: https://example.com)
+	DBPath    string // DB_PATH: SQLite 파일 (기본 restaurants.db, :memory:면 메모리 DB)
+	AssetDir  string // ASSET_DIR: index.html과 static/이 있는 폴더 (기본 현재 폴더)
+
+	KakaoJSKey        string // KAKAO_API_KEY: 지도용 JavaScript 키
+	KakaoRESTKey      string // REST_API_KEY: 로그인용 REST API 키
+	KakaoClientSecret string // KAKAO_CLIENT_SECRET
+	KakaoAuthURL      string // KAKAO_AUTH_URL (기본 https://kauth.kakao.com)
+	KakaoAPIURL       string // KAKAO_API_URL (기본 https://kapi.kakao.com)
+	AdminKakaoIDs     []int64
+	KakaoFake         bool     // KAKAO_FAKE: 내장 가짜 카카오 서버로 로그인 (개발/테스트용)
+	KakaoFakeUsers    []string // KAKAO_FAKE_USERS: 가짜 서버 테스트 사용자 (카카오ID:닉네임, 쉼표 구분)
+
+	SessionKeys   []string // SESSION_KEYS (쉼표 구분, 첫 번째 키로 서명)
+	SessionStore  string   // SESSION_STORE: cookie면 쿠키 저장소, 그 외에는 DB 저장소
+	SessionMaxAge int      // SESSION_MAX_AGE (초)
+	SessionSecure bool     // SESSION_SECURE (없으면 APP_DOMAIN이 https일 때만)
+}
+
+// Load: 환경변수에서 설정을 읽음
+func Load() Config {
+	cfg := Config{
+		Host:      os.Getenv("APP_HOST"),
+		Port:      os.Getenv("APP_PORT"),
+		AppDomain: os.Getenv("APP_DOMAIN"),
+		DBPath:    os.Getenv("DB_PATH"),
+		AssetDir:  os.Getenv("ASSET_DIR"),
+
+		KakaoJSKey:        os.Getenv("KAKAO_API_KEY"),
+		KakaoRESTKey:      os.Getenv("REST_API_KEY"),
+		KakaoClientSecret: os.Getenv("KAKAO_CLIENT_SECRET"),
+		KakaoAuthURL:      os.Getenv("KAKAO_AUTH_URL"),
+		KakaoAPIURL:       os.Getenv("KAKAO_API_URL"),
+		KakaoFakeUsers:    splitList(os.Getenv("KAKAO_FAKE_USERS")),
+
+		SessionKeys:  splitList(os.Getenv("SESSION_KEYS")),
+		SessionStore: os.Getenv("SESSION_STORE"),
+	}
+	if cfg.Host == "" {
+		cfg.Host = "0.0.0.0"
+	}
+	if cfg.Port == "" {
+		cfg.Port = "8080"
+	}
+	if cfg.DBPath == "" {
+		cfg.DBPath = defaultDBPath
+	}
+	if cfg.AssetDir == "" {
+		cfg.AssetDir = defaultAssetDir
+	}
+
+	for _, id := range splitList(os.Getenv("ADMIN_KAKAO_IDS")) {
+		if kakaoID, err := strconv.ParseInt(id, 10, 64); err == nil {
+			cfg.AdminKakaoIDs = append(cfg.AdminKakaoIDs, kakaoID)
+		}
+	}
+
+	cfg.KakaoFake, _ = strconv.ParseBool(os.Getenv("KAKAO_FAKE"))
+
+	maxAge, err := strconv.Atoi(os.Getenv("SESSION_MAX_AGE"))
+	if err != nil || maxAge <= 0 {
+		maxAge = DefaultSessionMaxAge
+	}
+	cfg.SessionMaxAge = maxAge
+
+	cfg.SessionSecure = strings.HasPrefix(cfg.AppDomain, "https://")
+	if v, err := strconv.ParseBool(os.Getenv("SESSION_SECURE")); err == nil {
+		cfg.SessionSecure = v
+	}
+	return cfg
+}
+
+// Addr: 서버가 바인딩할 주소
+func (c Config) Addr() string {
+	return c.Host + ":" + c.Port
+}
+
+// splitList: 쉼표로 구분된 값 (공백 제거, 빈 값 제외)
+func splitList(s string) []string {
+	var list []string
+	for _, v := range strings.Split(s, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			list = append(list, v)
+		}
+	}
+	return list
+}
@@ -0,0 +1,191 @@
+package handler
+
+import (
+	"net/http"
+	"slices"
+
+	"github.com/gin-gonic/gin"
+
+	"restaurant-api/internal/store"
+)
+
+// isAdmin: 사용자 역할이 admin이거나 ADMIN_KAKAO_IDS에 포함되어 있는지
+func (h *Handler) isAdmin(user store.User) bool {
+	return user.Role == store.RoleAdmin || slices.Contains(h.cfg.AdminKakaoIDs, user.KakaoID)
+}
+
+// requireAdmin: 로그인한 관리자만 통과시키는 미들웨어
+func (h *Handler) requireAdmin() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, ok := sessionUserID(c)
+		if !ok {
+			fail(c, "login_required")
+			return
+		}
+		var user store.User
+		if err := h.db.First(&user, userID).Error; err != nil || !h.isAdmin(user) {
+			fail(c, "admin_required")
+			return
+		}
+		c.Next()
+	}
+}
+
+// adminRoutes: 관리자 API (/admin 아래, requireAdmin 적용)
+func (h *Handler) adminRoutes(admin apiRoutes) {
+	// 식당 등록
+	admin.POST("/restaurants", h.createRestaurant)
+
+	// 식당 수정
+	admin.PUT("/restaurants/:id", h.updateRestaurant)
+
+	// 식당 삭제 (소프트 삭제)
+	admin.DELETE("/restaurants/:id", h.deleteRestaurant)
+
+	// 삭제된 식당 복구
+	admin.POST("/restaurants/:id/restore", h.restoreRestaurant)
+
+	// 영업시간/임시 휴무일 설정 (기존 값을 교체)
+	admin.PUT("/restaurants/:id/hours", h.setRestaurantHours)
+
+	// 메뉴 추가
+	admin.POST("/restaurants/:id/menu", h.createMenuItem)
+
+	// 메뉴 수정
+	admin.PUT("/menu/:id", h.updateMenuItem)
+
+	// 메뉴 삭제
+	admin.DELETE("/menu/:id", h.deleteMenuItem)
+
+	// 데이터 점검 (같은 장소 ID, 같은 좌표/다른 주소, 비슷한 이름)
+	admin.GET("/reports/data-quality", h.dataQualityReport)
+}
+
+func (h *Handler) createRestaurant(c *gin.Context) {
+	var in store.RestaurantInput
+	if err := c.ShouldBindJSON(&in); err != nil {
+		fail(c, "bad_request")
+		return
+	}
+	if errs := in.Validate(); len(errs) > 0 {
+		failFields(c, "invalid_input", errs)
+		return
+	}
+
+	res, err := store.CreateRestaurant(h.db, in)
+	if err != nil {
+		respondError(c, err, "식당 등록")
+		return
+	}
+	respond(c, http.StatusCreated, res)
+}
+
+func (h *Handler) updateRestaurant(c *gin.Context) {
+	var in store.RestaurantInput
+	if err := c.ShouldBindJSON(&in); err != nil {
+		fail(c, "bad_request")
+		return
+	}
+	if errs := in.Validate(); len(errs) > 0 {
+		failFields(c, "invalid_input", errs)
+		return
+	}
+
+	res, err := store.UpdateRestaurant(h.db, paramID(c, "id"), in)
+	if err != nil {
+		respondError(c, err, "식당 수정")
+		return
+	}
+	respond(c, http.StatusOK, res)
+}
+
+func (h *Handler) deleteRestaurant(c *gin.Context) {
+	if err := store.DeleteRestaurant(h.db, paramID(c, "id")); err != nil {
+		respondError(c, err, "식당 삭제")
+		return
+	}
+	respondMessage(c, "restaurant_deleted")
+}
+
+func (h *Handler) restoreRestaurant(c *gin.Context) {
+	res, err := store.RestoreRestaurant(h.db, paramID(c, "id"))
+	if err != nil {
+		respondError(c, err, "식당 복구")
+		return
+	}
+	respond(c, http.StatusOK, res)
+}
+
+func (h *Handler) setRestaurantHours(c *gin.Context) {
+	var in store.HoursInput
+	if err := c.ShouldBindJSON(&in); err != nil {
+		fail(c, "bad_request")
+		return
+	}
+	if errs := in.Validate(); len(errs) > 0 {
+		failFields(c, "invalid_input", errs)
+		return
+	}
+
+	res, err := store.SetRestaurantHours(h.db, paramID(c, "id"), in)
+	if err != nil {
+		respondError(c, err, "영업시간 설정")
+		return
+	}
+	respond(c, http.StatusOK, res)
+}
+
+func (h *Handler) createMenuItem(c *gin.Context) {
+	var in store.MenuItemInput
+	if err := c.ShouldBindJSON(&in); err != nil {
+		fail(c, "bad_request")
+		return
+	}
+	if errs := in.Validate(); len(errs) > 0 {
+		failFields(c, "invalid_input", errs)
+		return
+	}
+
+	item, err := store.CreateMenuItem(h.db, paramID(c, "id"), in)
+	if err != nil {
+		respondError(c, err, "메뉴 추가")
+		return
+	}
+	respond(c, http.StatusCreated, item)
+}
+
+func (h *Handler) updateMenuItem(c *gin.Context) {
+	var in store.MenuItemInput
+	if err := c.ShouldBindJSON(&in); err != nil {
+		fail(c, "bad_request")
+		return
+	}
+	if errs := in.Validate(); len(errs) > 0 {
+		failFields(c, "invalid_input", errs)
+		return
+	}
+
+	item, err := store.UpdateMenuItem(h.db, paramID(c, "id"), in)
+	if err != nil {
+		respondError(c, err, "메뉴 수정")
+		return
+	}
+	respond(c, http.StatusOK, item)
+}
+
+func (h *Handler) deleteMenuItem(c *gin.Context) {
+	if err := store.DeleteMenuItem(h.db, paramID(c, "id")); err != nil {
+		respondError(c, err, "메뉴 삭제")
+		return
+	}
+	respondMessage(c, "menu_item_deleted")
+}
+
+func (h *Handler) dataQualityReport(c *gin.Context) {
+	report, err := store.DataQualityReport(h.db)
+	if err != nil {
+		respondError(c, err, "데이터 점검")
+		return
+	}
+	respond(c, http.StatusOK, report)
+}
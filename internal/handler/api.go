@@ -0,0 +1,321 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"restaurant-api/internal/store"
+)
+
+// API 응답 형식
+//
+//	/api/v1/*  성공: {"data": ...}
+//	           실패: {"error": {"code": "list_not_found", "message": "목록을 찾을 수 없습니다.", "fields": {...}}}
+//	/api/*     기존 화면(static/app.js) 호환용. 사용 중단 예정이며 Deprecation 헤더를 붙인다.
+//	           성공: data를 그대로, 실패: {"error": "메시지", "fields": {...}}
+//
+// 메시지는 기본이 한국어이고, /api/v1과 로그인 화면은 ?lang=en 또는 Accept-Language: en이면 영어로 보낸다.
+
+const apiVersionKey = "apiVersion"
+
+// apiMessage: 응답 코드별 메시지
+type apiMessage struct {
+	Status int // 오류 코드의 HTTP 상태 (안내 메시지는 0)
+	Ko, En string
+}
+
+var apiMessages = map[string]apiMessage{
+	// 공통
+	"bad_request":     {http.StatusBadRequest, "요청 형식이 올바르지 않습니다.", "The request body is malformed."},
+	"invalid_input":   {http.StatusBadRequest, "입력 값을 확인해 주세요.", "Please check the highlighted fields."},
+	"login_required":  {http.StatusUnauthorized, "로그인이 필요합니다.", "You need to log in."},
+	"admin_required":  {http.StatusForbidden, "관리자 권한이 필요합니다.", "Administrator permission is required."},
+	"route_not_found": {http.StatusNotFound, "없는 API입니다.", "No such API endpoint."},
+	"internal_error":  {http.StatusInternalServerError, "요청을 처리하지 못했습니다. 잠시 후 다시 시도해 주세요.", "Something went wrong. Please try again later."},
+
+	// 목록/추천 필터
+	"invalid_geo":        {http.StatusBadRequest, fmt.Sprintf("lat/lng/radius 값이 올바르지 않습니다. (반경은 최대 %.0fm)", store.MaxRadius), fmt.Sprintf("lat/lng/radius are invalid (radius up to %.0fm).", store.MaxRadius)},
+	"invalid_min_rating": {http.StatusBadRequest, "min_rating은 0~5 사이여야 합니다.", "min_rating must be between 0 and 5."},
+	"invalid_exclude":    {http.StatusBadRequest, "exclude는 쉼표로 구분한 식당 ID여야 합니다.", "exclude must be comma-separated restaurant IDs."},
+	"invalid_price":      {http.StatusBadRequest, "min_price/max_price는 1~1,000,000 사이의 원 단위 금액이어야 합니다.", "min_price/max_price must be amounts in won between 1 and 1,000,000."},
+	"invalid_open_at":    {http.StatusBadRequest, "open_now는 true/false, open_at은 HH:MM 또는 YYYY-MM-DDTHH:MM 형식이며 둘 중 하나만 지정할 수 있습니다.", "open_now must be true/false and open_at HH:MM or YYYY-MM-DDTHH:MM; use only one of them."},
+	"invalid_sort":       {http.StatusBadRequest, "지원하지 않는 정렬 기준입니다. (distance는 lat/lng, relevance는 search 필요)", "Unsupported sort (distance needs lat/lng, relevance needs search)."},
+	"no_candidates":      {http.StatusNotFound, "조건에 맞는 식당이 없습니다.", "No restaurant matches the conditions."},
+
+	// 식당/별점/리뷰/메뉴
+	"restaurant_not_found":   {http.StatusNotFound, "식당을 찾을 수 없습니다.", "Restaurant not found."},
+	"restaurant_not_deleted": {http.StatusConflict, "삭제되지 않은 식당입니다.", "The restaurant is not deleted."},
+	"duplicate_place_id":     {http.StatusConflict, "이미 등록된 장소입니다.", "This place is already registered."},
+	"rating_not_found":       {http.StatusNotFound, "남긴 별점이 없습니다.", "You have not rated this restaurant."},
+	"review_not_found":       {http.StatusNotFound, "리뷰를 찾을 수 없습니다.", "Review not found."},
+	"review_empty":           {http.StatusBadRequest, "리뷰 내용을 입력해 주세요.", "Please write the review."},
+	"review_too_long":        {http.StatusBadRequest, fmt.Sprintf("리뷰는 %d자 이하로 작성해 주세요.", store.MaxReviewLength), fmt.Sprintf("Reviews can be up to %d characters.", store.MaxReviewLength)},
+	"menu_item_not_found":    {http.StatusNotFound, "메뉴를 찾을 수 없습니다.", "Menu item not found."},
+
+	// 즐겨찾기/목록
+	"list_not_found":      {http.StatusNotFound, "목록을 찾을 수 없습니다.", "List not found."},
+	"invalid_list_order":  {http.StatusBadRequest, "목록에 담긴 식당 ID를 빠짐없이 한 번씩 보내 주세요.", "Send every restaurant ID in the list exactly once."},
+	"too_many_lists":      {http.StatusConflict, fmt.Sprintf("목록은 최대 %d개까지 만들 수 있습니다.", store.MaxListsPerUser), fmt.Sprintf("You can create up to %d lists.", store.MaxListsPerUser)},
+	"too_many_list_items": {http.StatusConflict, fmt.Sprintf("목록에는 최대 %d곳까지 담을 수 있습니다.", store.MaxListItems), fmt.Sprintf("A list can hold up to %d restaurants.", store.MaxListItems)},
+
+	// 같이 먹기 방
+	"room_not_found":      {http.StatusNotFound, "방을 찾을 수 없습니다. 코드를 확인해 주세요.", "Room not found. Please check the code."},
+	"not_room_member":     {http.StatusForbidden, "먼저 방에 참여해 주세요.", "Join the room first."},
+	"not_room_owner":      {http.StatusForbidden, "방장만 투표를 마감할 수 있습니다.", "Only the room owner can close the vote."},
+	"room_closed":         {http.StatusConflict, "이미 마감된 방입니다.", "The room is already closed."},
+	"candidate_not_found": {http.StatusNotFound, "후보를 찾을 수 없습니다.", "Candidate not found."},
+	"duplicate_candidate": {http.StatusConflict, "이미 후보에 있는 식당입니다.", "The restaurant is already a candidate."},
+	"too_many_candidates": {http.StatusConflict, fmt.Sprintf("후보는 최대 %d곳까지 올릴 수 있습니다.", store.MaxRoomCandidates), fmt.Sprintf("A room can have up to %d candidates.", store.MaxRoomCandidates)},
+	"room_no_candidates":  {http.StatusConflict, "후보가 없어 마감할 수 없습니다.", "There are no candidates to close the vote with."},
+
+	// 카카오 로그인
+	"login_unavailable":   {http.StatusInternalServerError, "로그인을 시작할 수 없습니다.", "Cannot start login."},
+	"invalid_oauth_state": {http.StatusBadRequest, "잘못된 로그인 요청입니다. 다시 시도해 주세요.", "Invalid login request. Please try again."},
+	"missing_auth_code":   {http.StatusBadRequest, "인가 코드가 없습니다.", "The authorization code is missing."},
+	"invalid_auth_code":   {http.StatusBadRequest, "인가 코드가 유효하지 않습니다. 다시 로그인해 주세요.", "The authorization code is invalid. Please log in again."},
+	"kakao_unavailable":   {http.StatusBadGateway, "카카오 서버와 통신하지 못했습니다.", "Could not reach Kakao."},
+
+	// 안내 메시지
+	"rated":              {0, "평가가 완료되었습니다.", "Your rating has been saved."},
+	"rating_retracted":   {0, "평가가 철회되었습니다.", "Your rating has been withdrawn."},
+	"review_deleted":     {0, "리뷰가 삭제되었습니다.", "The review has been deleted."},
+	"favorite_added":     {0, "즐겨찾기에 추가되었습니다.", "Added to favorites."},
+	"favorite_removed":   {0, "즐겨찾기에서 삭제되었습니다.", "Removed from favorites."},
+	"list_deleted":       {0, "목록이 삭제되었습니다.", "The list has been deleted."},
+	"list_item_added":    {0, "목록에 추가되었습니다.", "Added to the list."},
+	"list_item_removed":  {0, "목록에서 삭제되었습니다.", "Removed from the list."},
+	"list_reordered":     {0, "순서가 변경되었습니다.", "The order has been updated."},
+	"restaurant_deleted": {0, "식당이 삭제되었습니다.", "The restaurant has been deleted."},
+	"menu_item_deleted":  {0, "메뉴가 삭제되었습니다.", "The menu item has been deleted."},
+}
+
+// 도메인 오류 → 응답 코드 (fields: 함께 보낼 필드별 오류 코드)
+var errorCodes = []struct {
+	err    error
+	code   string
+	fields map[string]string
+}{
+	{store.ErrInvalidGeo, "invalid_geo", nil},
+	{errInvalidMinRating, "invalid_min_rating", nil},
+	{errInvalidExclude, "invalid_exclude", nil},
+	{store.ErrInvalidPrice, "invalid_price", nil},
+	{store.ErrInvalidOpenAt, "invalid_open_at", nil},
+	{store.ErrInvalidSort, "invalid_sort", nil},
+	{store.ErrNoCandidates, "no_candidates", nil},
+	{store.ErrRestaurantNotFound, "restaurant_not_found", nil},
+	{store.ErrRestaurantNotDeleted, "restaurant_not_deleted", nil},
+	{store.ErrDuplicatePlaceID, "duplicate_place_id", map[string]string{"url": "url.duplicate_place"}},
+	{store.ErrRatingNotFound, "rating_not_found", nil},
+	{store.ErrReviewNotFound, "review_not_found", nil},
+	{store.ErrReviewEmpty, "review_empty", nil},
+	{store.ErrReviewTooLong, "review_too_long", nil},
+	{store.ErrMenuItemNotFound, "menu_item_not_found", nil},
+	{store.ErrListNotFound, "list_not_found", nil},
+	{store.ErrInvalidListOrder, "invalid_list_order", nil},
+	{store.ErrTooManyLists, "too_many_lists", nil},
+	{store.ErrTooManyListItems, "too_many_list_items", nil},
+	{store.ErrRoomNotFound, "room_not_found", nil},
+	{store.ErrNotRoomMember, "not_room_member", nil},
+	{store.ErrNotRoomOwner, "not_room_owner", nil},
+	{store.ErrRoomClosed, "room_closed", nil},
+	{store.ErrCandidateNotFound, "candidate_not_found", nil},
+	{store.ErrDuplicateCandidate, "duplicate_candidate", nil},
+	{store.ErrTooManyCandidates, "too_many_candidates", nil},
+	{store.ErrRoomNoCandidates, "room_no_candidates", nil},
+}
+
+// 필드별 오류 코드 → 메시지 (ko, en). validate()와 failFields는 코드로 주고받는다
+var fieldMessages = map[string][2]string{
+	// 식당/메뉴/영업시간 (store)
+	"title.length":            {"식당 이름은 1~100자여야 합니다.", "Title must be 1-100 characters."},
+	"addr.length":             {"주소는 1~200자여야 합니다.", "Address must be 1-200 characters."},
+	"y.range":                 {"위도(y)가 올바르지 않습니다.", "Latitude (y) is invalid."},
+	"x.range":                 {"경도(x)가 올바르지 않습니다.", "Longitude (x) is invalid."},
+	"url.invalid":             {"http(s) 주소를 입력해 주세요.", "Enter an http(s) URL."},
+	"url.duplicate_place":     {"같은 카카오 장소 URL을 쓰는 식당이 있습니다.", "Another restaurant uses the same Kakao place URL."},
+	"list_name.length":        {"목록 이름은 1~30자여야 합니다.", "List name must be 1-30 characters."},
+	"menu_name.length":        {"메뉴 이름은 1~50자여야 합니다.", "Menu name must be 1-50 characters."},
+	"price.range":             {"가격은 1~1,000,000원이어야 합니다.", "Price must be between 1 and 1,000,000 won."},
+	"hours.weekday.range":     {"요일은 0(일)~6(토)이어야 합니다.", "Weekday must be 0 (Sun) to 6 (Sat)."},
+	"hours.open.format":       {"개점 시각은 HH:MM 형식이어야 합니다.", "Opening time must be HH:MM."},
+	"hours.close.format":      {"마감 시각은 HH:MM 형식이어야 합니다.", "Closing time must be HH:MM."},
+	"hours.break.format":      {"브레이크 타임은 같은 날 HH:MM~HH:MM 형식이어야 합니다.", "Break time must be HH:MM-HH:MM within the same day."},
+	"closures.date.format":    {"휴무일은 YYYY-MM-DD 형식이어야 합니다.", "Closure date must be YYYY-MM-DD."},
+	"closures.date.duplicate": {"같은 휴무일이 중복되었습니다.", "Duplicate closure date."},
+
+	// 요청 값 (handler)
+	"restaurant_id.required":  {"식당 ID가 필요합니다.", "A restaurant ID is required."},
+	"restaurant_id.not_found": {"존재하지 않는 식당입니다.", "Restaurant not found."},
+	"restaurant_ids.required": {"식당 ID 목록이 필요합니다.", "A list of restaurant IDs is required."},
+	"candidate_id.required":   {"후보 ID가 필요합니다.", "A candidate ID is required."},
+	"score.range":             {"별점은 1~5점, 0.5점 단위여야 합니다.", "Score must be 1-5 in half-star steps."},
+	"review.length":           {fmt.Sprintf("리뷰는 %d자 이하여야 합니다.", store.MaxReviewLength), fmt.Sprintf("Review must be at most %d characters.", store.MaxReviewLength)},
+	"review.no_fields":        {"review, pros, cons 중 하나 이상이 필요합니다.", "Send at least one of review, pros and cons."},
+}
+
+// Message: 안내 메시지만 있는 응답
+type Message struct {
+	Message string `json:"message"`
+}
+
+// apiVersion: 응답 형식을 정하는 미들웨어 (1: /api/v1, 0: 구버전 /api)
+func apiVersion(version int) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Set(apiVersionKey, version)
+		if version == 0 {
+			c.Header("Deprecation", "true")
+			c.Header("Link", `</api/v1`+strings.TrimPrefix(c.Request.URL.Path, "/api")+`>; rel="successor-version"`)
+		}
+		c.Next()
+	}
+}
+
+// isV1: /api/v1 요청인지
+func isV1(c *gin.Context) bool {
+	return c.GetInt(apiVersionKey) == 1
+}
+
+// locale: 응답 언어 (ko, en). 구버전 API는 항상 한국어
+func locale(c *gin.Context) string {
+	if v, ok := c.Get(apiVersionKey); ok && v == 0 {
+		return "ko"
+	}
+	lang := c.Query("lang")
+	if lang == "" {
+		lang = c.GetHeader("Accept-Language")
+	}
+	if strings.HasPrefix(strings.ToLower(strings.TrimSpace(lang)), "en") {
+		return "en"
+	}
+	return "ko"
+}
+
+// message: 코드에 맞는 메시지 (요청 언어 기준)
+func message(c *gin.Context, code string) string {
+	m := apiMessages[code]
+	if locale(c) == "en" {
+		return m.En
+	}
+	return m.Ko
+}
+
+// respond: 성공 응답
+func respond(c *gin.Context, status int, data any) {
+	if isV1(c) {
+		c.JSON(status, gin.H{"data": data})
+		return
+	}
+	c.JSON(status, data)
+}
+
+// respondMessage: 안내 메시지만 있는 성공 응답
+func respondMessage(c *gin.Context, code string) {
+	respond(c, http.StatusOK, Message{Message: message(c, code)})
+}
+
+// fail: 오류 응답 후 중단 (미들웨어에서도 사용)
+func fail(c *gin.Context, code string) {
+	failFields(c, code, nil)
+}
+
+// failFields: 필드별 오류 코드(fieldMessages)를 요청 언어의 메시지로 바꿔 오류 응답 후 중단
+func failFields(c *gin.Context, code string, fields map[string]string) {
+	var messages map[string]string
+	if len(fields) > 0 {
+		lang := 0
+		if locale(c) == "en" {
+			lang = 1
+		}
+		messages = make(map[string]string, len(fields))
+		for field, fieldCode := range fields {
+			m, ok := fieldMessages[fieldCode]
+			if !ok {
+				log.Println("WARN  알 수 없는 필드 오류 코드:", fieldCode)
+				messages[field] = fieldCode
+				continue
+			}
+			messages[field] = m[lang]
+		}
+	}
+	failMessages(c, code, messages)
+}
+
+// failMessages: 이미 요청 언어로 만든 필드별 메시지를 포함한 오류 응답 후 중단 (스키마 검사용)
+func failMessages(c *gin.Context, code string, fields map[string]string) {
+	status := apiMessages[code].Status
+	if status == 0 {
+		status = http.StatusInternalServerError
+	}
+
+	if isV1(c) {
+		body := gin.H{"code": code, "message": message(c, code)}
+		if len(fields) > 0 {
+			body["fields"] = fields
+		}
+		c.AbortWithStatusJSON(status, gin.H{"error": body})
+		return
+	}
+	body := gin.H{"error": message(c, code)}
+	if len(fields) > 0 {
+		body["fields"] = fields
+	}
+	c.AbortWithStatusJSON(status, body)
+}
+
+// respondError: 도메인 오류를 응답 코드로 바꿔 응답. 알 수 없는 오류는 기록하고 internal_error
+func respondError(c *gin.Context, err error, action string) {
+	for _, e := range errorCodes {
+		if errors.Is(err, e.err) {
+			failFields(c, e.code, e.fields)
+			return
+		}
+	}
+	log.Printf("ERROR %s 실패: %v\n", action, err)
+	fail(c, "internal_error")
+}
+
+// apiRoutes: 같은 핸들러를 /api/v1과 구버전 /api 양쪽에 등록
+// validate가 있으면 마지막 핸들러 바로 앞(로그인/관리자 확인 다음)에 요청 검사를 끼움
+type apiRoutes struct {
+	groups   []*gin.RouterGroup
+	validate gin.HandlerFunc
+}
+
+func (rs apiRoutes) Group(path string, handlers ...gin.HandlerFunc) apiRoutes {
+	groups := make([]*gin.RouterGroup, len(rs.groups))
+	for i, g := range rs.groups {
+		groups[i] = g.Group(path, handlers...)
+	}
+	return apiRoutes{groups: groups, validate: rs.validate}
+}
+
+func (rs apiRoutes) handle(method, path string, handlers []gin.HandlerFunc) {
+	if rs.validate != nil && len(handlers) > 0 {
+		last := len(handlers) - 1
+		handlers = append(append(handlers[:last:last], rs.validate), handlers[last])
+	}
+	for _, g := range rs.groups {
+		g.Handle(method, path, handlers...)
+	}
+}
+
+func (rs apiRoutes) GET(path string, handlers ...gin.HandlerFunc) {
+	rs.handle(http.MethodGet, path, handlers)
+}
+
+func (rs apiRoutes) POST(path string, handlers ...gin.HandlerFunc) {
+	rs.handle(http.MethodPost, path, handlers)
+}
+
+func (rs apiRoutes) PUT(path string, handlers ...gin.HandlerFunc) {
+	rs.handle(http.MethodPut, path, handlers)
+}
+
+func (rs apiRoutes) DELETE(path string, handlers ...gin.HandlerFunc) {
+	rs.handle(http.MethodDelete, path, handlers)
+}
@@ -0,0 +1,139 @@
+package handler
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/gin-contrib/sessions"
+	"github.com/gin-gonic/gin"
+
+	"restaurant-api/internal/kakao"
+	"restaurant-api/internal/store"
+)
+
+// newOAuthState: 카카오 로그인 요청마다 발급하는 CSRF 방지용 state 값
+func newOAuthState() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// validOAuthState: 콜백으로 돌아온 state가 세션에 저장한 값과 같은지 확인
+func validOAuthState(expected any, got string) bool {
+	want, ok := expected.(string)
+	if !ok || want == "" || got == "" {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(want), []byte(got)) == 1
+}
+
+// safeNextPath: 로그인 후 돌아갈 경로. 같은 사이트 안의 절대 경로만 허용하고 나머지는 "/"
+func safeNextPath(next string) string {
+	if next == "" || !strings.HasPrefix(next, "/") || strings.HasPrefix(next, "//") || strings.ContainsAny(next, "\\\r\n") {
+		return "/"
+	}
+	u, err := url.Parse(next)
+	if err != nil || u.Scheme != "" || u.Host != "" || u.User != nil {
+		return "/"
+	}
+	return u.RequestURI()
+}
+
+// kakaoRedirectURI: 카카오 앱에 등록한 로그인 콜백 주소
+func (h *Handler) kakaoRedirectURI() string {
+	return h.cfg.AppDomain + "/auth/kakao/callback"
+}
+
+// loginKakao: 카카오 로그인 시작 (next: 로그인 후 돌아갈 경로)
+func (h *Handler) loginKakao(c *gin.Context) {
+	state, err := newOAuthState()
+	if err != nil {
+		log.Println("ERROR state 생성 실패:", err)
+		c.String(http.StatusInternalServerError, message(c, "login_unavailable"))
+		return
+	}
+	session := sessions.Default(c)
+	session.Set("oauthState", state)
+	session.Set("loginNext", safeNextPath(c.Query("next")))
+	session.Save()
+	c.Redirect(http.StatusFound, h.kakao.AuthorizeURL(h.kakaoRedirectURI(), state))
+}
+
+// kakaoCallback: 카카오 콜백 처리 (브라우저가 보는 화면이므로 오류는 텍스트로 응답)
+func (h *Handler) kakaoCallback(c *gin.Context) {
+	// state는 한 번만 사용할 수 있도록 확인 즉시 세션에서 제거
+	session := sessions.Default(c)
+	expectedState := session.Get("oauthState")
+	next, _ := session.Get("loginNext").(string)
+	session.Delete("oauthState")
+	session.Delete("loginNext")
+	session.Save()
+
+	if !validOAuthState(expectedState, c.Query("state")) {
+		log.Println("WARN  카카오 콜백 state 불일치")
+		c.String(http.StatusBadRequest, message(c, "invalid_oauth_state"))
+		return
+	}
+
+	code := c.Query("code")
+	if code == "" {
+		c.String(http.StatusBadRequest, message(c, "missing_auth_code"))
+		return
+	}
+
+	tokenRes, err := h.kakao.Token(code, h.kakaoRedirectURI())
+	if err != nil {
+		log.Println("ERROR 카카오 토큰 발급 실패:", err)
+		var kakaoErr *kakao.Error
+		if errors.As(err, &kakaoErr) && kakaoErr.StatusCode < 500 {
+			c.String(http.StatusBadRequest, message(c, "invalid_auth_code"))
+			return
+		}
+		c.String(http.StatusBadGateway, message(c, "kakao_unavailable"))
+		return
+	}
+
+	userInfo, err := h.kakao.UserInfo(tokenRes.AccessToken)
+	if err != nil {
+		log.Println("ERROR 카카오 사용자 정보 조회 실패:", err)
+		c.String(http.StatusBadGateway, message(c, "kakao_unavailable"))
+		return
+	}
+
+	user, err := store.UpsertKakaoUser(h.db, userInfo.ID, userInfo.Nickname())
+	if err != nil {
+		log.Println("ERROR 사용자 저장 실패:", err)
+		c.String(http.StatusInternalServerError, message(c, "internal_error"))
+		return
+	}
+
+	// DB 저장소는 사용자가 바뀌면 로그인 전 세션 행을 지우고 새 세션 ID를 발급한다 (session.go의 rotateOnLogin)
+	session.Set("userID", user.ID)
+	session.Set("userName", user.Nickname)
+	if err := session.Save(); err != nil {
+		log.Println("ERROR 로그인 세션 저장 실패:", err)
+		c.String(http.StatusInternalServerError, message(c, "internal_error"))
+		return
+	}
+
+	c.Redirect(http.StatusFound, safeNextPath(next))
+}
+
+// logout: 세션 삭제 (DB 저장소에서는 서버 측 세션도 함께 삭제)
+func (h *Handler) logout(c *gin.Context) {
+	session := sessions.Default(c)
+	session.Clear()
+	opts := sessionOptions(h.cfg)
+	opts.MaxAge = -1
+	session.Options(opts)
+	session.Save()
+	c.Redirect(http.StatusFound, "/")
+}
@@ -0,0 +1,143 @@
+// Package handler: HTTP 라우트와 핸들러. DB와 카카오 클라이언트는 New로 주입받는다.
+package handler
+
+import (
+	"context"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/gin-contrib/sessions"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"restaurant-api/internal/config"
+	"restaurant-api/internal/kakao"
+)
+
+// KakaoClient: 로그인에 쓰는 카카오 API (테스트에서는 가짜 클라이언트로 바꿔 끼운다)
+type KakaoClient interface {
+	AuthorizeURL(redirectURI, state string) string
+	Token(code, redirectURI string) (*kakao.TokenResponse, error)
+	UserInfo(token string) (*kakao.UserResponse, error)
+}
+
+// Handler: 라우트 핸들러가 함께 쓰는 의존성
+type Handler struct {
+	cfg      config.Config
+	db       *gorm.DB
+	kakao    KakaoClient
+	sessions sessions.Store
+	spec     *openAPI
+	rooms    *roomHub
+	shutdown <-chan struct{} // 서버 종료 시 닫힘 (실시간 알림 연결을 끝내는 데 사용)
+}
+
+// New: 설정, DB, 카카오 클라이언트로 핸들러 생성 (세션 저장소는 cfg.SessionStore에 따라).
+// ctx가 끝나면 실시간 알림(SSE) 연결을 닫아 서버가 바로 종료될 수 있게 한다
+func New(ctx context.Context, cfg config.Config, db *gorm.DB, kc KakaoClient) *Handler {
+	return &Handler{
+		cfg:      cfg,
+		db:       db,
+		kakao:    kc,
+		sessions: newSessionStore(cfg, db),
+		spec:     newOpenAPI(),
+		rooms:    newRoomHub(),
+		shutdown: ctx.Done(),
+	}
+}
+
+// Register: 세션 미들웨어와 모든 라우트 등록
+func (h *Handler) Register(r *gin.Engine) {
+	r.Use(sessions.Sessions(sessionName, h.sessions))
+
+	// 메인 페이지
+	r.GET("/", h.index)
+
+	// 카카오 로그인/로그아웃
+	r.GET("/login/kakao", h.loginKakao)
+	r.GET("/auth/kakao/callback", h.kakaoCallback)
+	r.GET("/logout", h.logout)
+
+	// API는 /api/v1 아래에 두고, 기존 /api 경로는 사용 중단 예정 별칭으로 유지 (응답 형식은 api.go 참고)
+	// 요청은 OpenAPI 문서(openapi.go)의 스키마로 먼저 검사
+	api := apiRoutes{
+		groups:   []*gin.RouterGroup{r.Group("/api/v1", apiVersion(1)), r.Group("/api", apiVersion(0))},
+		validate: h.spec.validate(),
+	}
+
+	// API 문서 (OpenAPI 3 JSON과 문서 화면)
+	r.GET("/api/openapi.json", func(c *gin.Context) {
+		c.JSON(http.StatusOK, h.spec.doc)
+	})
+	r.GET("/api/docs", func(c *gin.Context) {
+		c.File(filepath.Join(h.cfg.AssetDir, "static", "docs.html"))
+	})
+
+	h.restaurantRoutes(api)
+	h.ratingRoutes(api)
+
+	me := api.Group("", requireLogin())
+	h.listRoutes(api, me)
+	h.roomRoutes(me)
+
+	h.adminRoutes(api.Group("/admin", h.requireAdmin()))
+
+	// 없는 /api/v1 경로도 같은 오류 형식으로 응답
+	r.NoRoute(func(c *gin.Context) {
+		if strings.HasPrefix(c.Request.URL.Path, "/api/v1/") {
+			c.Set(apiVersionKey, 1)
+			fail(c, "route_not_found")
+			return
+		}
+		c.String(http.StatusNotFound, "404 page not found")
+	})
+
+	h.spec.checkRoutes(r.Routes())
+}
+
+// index: 메인 페이지
+func (h *Handler) index(c *gin.Context) {
+	session := sessions.Default(c)
+	userName := session.Get("userName")
+	c.HTML(http.StatusOK, "index.html", gin.H{
+		"ApiKey":     h.cfg.KakaoJSKey,
+		"IsLoggedIn": session.Get("userID") != nil,
+		"UserName":   userName,
+		"AppDomain":  h.cfg.AppDomain,
+	})
+}
+
+// --- [도움 함수] ---
+
+// sessionUserID: 세션에 저장된 로그인 사용자 ID
+func sessionUserID(c *gin.Context) (uint, bool) {
+	userID, ok := sessions.Default(c).Get("userID").(uint)
+	return userID, ok
+}
+
+// pageParams: limit/offset(또는 cursor) 쿼리 파라미터 (기본 20개, 최대 100개)
+func pageParams(c *gin.Context) (int, int) {
+	limit, err := strconv.Atoi(c.Query("limit"))
+	if err != nil || limit <= 0 {
+		limit = 20
+	}
+	if limit > 100 {
+		limit = 100
+	}
+	if offset, ok := decodeCursor(c.Query("cursor")); ok {
+		return limit, offset
+	}
+	offset, err := strconv.Atoi(c.Query("offset"))
+	if err != nil || offset < 0 {
+		offset = 0
+	}
+	return limit, offset
+}
+
+// paramID: 경로 파라미터의 ID (숫자가 아니면 0)
+func paramID(c *gin.Context, name string) uint {
+	id, _ := strconv.Atoi(c.Param(name))
+	return uint(id)
+}
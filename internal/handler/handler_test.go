@@ -0,0 +1,432 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"restaurant-api/internal/config"
+	"restaurant-api/internal/kakao"
+	"restaurant-api/internal/store"
+)
+
+// stubKakao: 네트워크 없이 로그인하는 카카오 클라이언트. 인가 코드 "user-<카카오ID>"로 그 사용자가 로그인한다
+type stubKakao struct{}
+
+func (stubKakao) AuthorizeURL(redirectURI, state string) string {
+	return "https://kauth.test/oauth/authorize?" + url.Values{"redirect_uri": {redirectURI}, "state": {state}}.Encode()
+}
+
+func (stubKakao) Token(code, redirectURI string) (*kakao.TokenResponse, error) {
+	id, ok := strings.CutPrefix(code, "user-")
+	if !ok {
+		return nil, &kakao.Error{StatusCode: http.StatusBadRequest, ErrorType: "invalid_grant", ErrorCode: "KOE320"}
+	}
+	return &kakao.TokenResponse{AccessToken: "token-" + id}, nil
+}
+
+func (stubKakao) UserInfo(token string) (*kakao.UserResponse, error) {
+	id, err := strconv.ParseInt(strings.TrimPrefix(token, "token-"), 10, 64)
+	if err != nil {
+		return nil, &kakao.Error{StatusCode: http.StatusUnauthorized, Code: -401}
+	}
+	var res kakao.UserResponse
+	res.ID = id
+	res.Properties.Nickname = fmt.Sprintf("사용자%d", id)
+	return &res, nil
+}
+
+const testAdminKakaoID = 9001
+
+// newTestServer: 메모리 DB와 stubKakao로 만든 라우터 (식당 두 곳과 메뉴가 들어 있음)
+func newTestServer(t *testing.T) (*gin.Engine, *gorm.DB) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	db, err := store.Open(":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if sqlDB, err := db.DB(); err == nil {
+			sqlDB.Close()
+		}
+	})
+
+	for _, seed := range []struct {
+		in   store.RestaurantInput
+		menu store.MenuItemInput
+	}{
+		{store.RestaurantInput{Title: "가야밀면", Addr: "경기 안양시 만안구 성결대학로 1", Food: "한식,밀면", X: 126.93, Y: 37.38, URL: "https://place.map.kakao.com/1"},
+			store.MenuItemInput{Name: "물밀면", Price: 9000, Signature: true}},
+		{store.RestaurantInput{Title: "남촌김밥", Addr: "경기 안양시 만안구 안양로 110", Food: "분식", X: 126.92, Y: 37.39, URL: "https://place.map.kakao.com/2"},
+			store.MenuItemInput{Name: "참치김밥", Price: 4500, Signature: true}},
+	} {
+		res, err := store.CreateRestaurant(db, seed.in)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := store.CreateMenuItem(db, res.ID, seed.menu); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return newTestRouter(context.Background(), db), db
+}
+
+// newTestRouter: db를 쓰는 라우터 (ctx가 끝나면 실시간 알림 연결을 닫음)
+func newTestRouter(ctx context.Context, db *gorm.DB) *gin.Engine {
+	cfg := config.Config{SessionKeys: []string{"test-session-key"}, AdminKakaoIDs: []int64{testAdminKakaoID}}
+	r := gin.New()
+	New(ctx, cfg, db, stubKakao{}).Register(r)
+	return r
+}
+
+// testClient: 쿠키를 이어 가며 요청을 보내는 브라우저 흉내
+type testClient struct {
+	t       *testing.T
+	r       *gin.Engine
+	cookies map[string]*http.Cookie
+}
+
+func newTestClient(t *testing.T, r *gin.Engine) *testClient {
+	return &testClient{t: t, r: r, cookies: map[string]*http.Cookie{}}
+}
+
+// do: body가 있으면 JSON으로 보냄
+func (tc *testClient) do(method, path string, body any) *httptest.ResponseRecorder {
+	tc.t.Helper()
+	if body == nil {
+		return tc.doRaw(method, path, "", "")
+	}
+	raw, err := json.Marshal(body)
+	if err != nil {
+		tc.t.Fatal(err)
+	}
+	return tc.doRaw(method, path, "application/json", string(raw))
+}
+
+// doRaw: 본문과 Content-Type을 그대로 보냄 (contentType이 비어 있으면 헤더 없음)
+func (tc *testClient) doRaw(method, path, contentType, body string) *httptest.ResponseRecorder {
+	tc.t.Helper()
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	for _, ck := range tc.cookies {
+		req.AddCookie(ck)
+	}
+
+	w := httptest.NewRecorder()
+	tc.r.ServeHTTP(w, req)
+	for _, ck := range w.Result().Cookies() {
+		if ck.MaxAge < 0 {
+			delete(tc.cookies, ck.Name)
+		} else {
+			tc.cookies[ck.Name] = ck
+		}
+	}
+	return w
+}
+
+// login: /login/kakao → 콜백 순서로 로그인
+func (tc *testClient) login(kakaoID int64) {
+	tc.t.Helper()
+	w := tc.do(http.MethodGet, "/login/kakao", nil)
+	loc, err := url.Parse(w.Header().Get("Location"))
+	if w.Code != http.StatusFound || err != nil {
+		tc.t.Fatalf("login start: %d %q", w.Code, w.Header().Get("Location"))
+	}
+	q := url.Values{"state": {loc.Query().Get("state")}, "code": {fmt.Sprintf("user-%d", kakaoID)}}
+	if w := tc.do(http.MethodGet, "/auth/kakao/callback?"+q.Encode(), nil); w.Code != http.StatusFound {
+		tc.t.Fatalf("login callback: %d %s", w.Code, w.Body)
+	}
+}
+
+// apiEnvelope: /api/v1 응답 봉투
+type apiEnvelope struct {
+	Data  json.RawMessage `json:"data"`
+	Error *struct {
+		Code    string            `json:"code"`
+		Message string            `json:"message"`
+		Fields  map[string]string `json:"fields"`
+	} `json:"error"`
+}
+
+func decodeEnvelope(t *testing.T, w *httptest.ResponseRecorder, wantStatus int, data any) apiEnvelope {
+	t.Helper()
+	if w.Code != wantStatus {
+		t.Fatalf("status = %d, want %d: %s", w.Code, wantStatus, w.Body)
+	}
+	var env apiEnvelope
+	if err := json.Unmarshal(w.Body.Bytes(), &env); err != nil {
+		t.Fatalf("decode %s: %v", w.Body, err)
+	}
+	if data != nil {
+		if err := json.Unmarshal(env.Data, data); err != nil {
+			t.Fatalf("decode data %s: %v", env.Data, err)
+		}
+	}
+	return env
+}
+
+func expectError(t *testing.T, w *httptest.ResponseRecorder, wantStatus int, wantCode string) apiEnvelope {
+	t.Helper()
+	env := decodeEnvelope(t, w, wantStatus, nil)
+	if env.Error == nil || env.Error.Code != wantCode {
+		t.Fatalf("error = %s, want code %q", w.Body, wantCode)
+	}
+	return env
+}
+
+func TestListRestaurants(t *testing.T) {
+	r, _ := newTestServer(t)
+	tc := newTestClient(t, r)
+
+	var page RestaurantPage
+	decodeEnvelope(t, tc.do(http.MethodGet, "/api/v1/restaurants?sort=name", nil), http.StatusOK, &page)
+	if page.Total != 2 || len(page.Restaurants) != 2 || page.Restaurants[0].Title != "가야밀면" {
+		t.Fatalf("page = %+v", page)
+	}
+
+	decodeEnvelope(t, tc.do(http.MethodGet, "/api/v1/restaurants?category=분식", nil), http.StatusOK, &page)
+	if page.Total != 1 || page.Restaurants[0].Title != "남촌김밥" {
+		t.Fatalf("category page = %+v", page)
+	}
+
+	expectError(t, tc.do(http.MethodGet, "/api/v1/restaurants?exclude=abc", nil), http.StatusBadRequest, "invalid_exclude")
+}
+
+func TestSearchRestaurants(t *testing.T) {
+	r, _ := newTestServer(t)
+	tc := newTestClient(t, r)
+
+	for _, q := range []string{"김밥", "ㄴㅊ", "남촌김빱"} {
+		var page RestaurantPage
+		decodeEnvelope(t, tc.do(http.MethodGet, "/api/v1/restaurants?search="+url.QueryEscape(q), nil), http.StatusOK, &page)
+		if page.Total != 1 || page.Restaurants[0].Title != "남촌김밥" {
+			t.Fatalf("search %q = %+v", q, page)
+		}
+		if page.Restaurants[0].Highlights["title"] == "" {
+			t.Errorf("search %q: no title highlight", q)
+		}
+	}
+
+	var sug struct {
+		Suggestions []store.Suggestion `json:"suggestions"`
+	}
+	decodeEnvelope(t, tc.do(http.MethodGet, "/api/v1/search/suggest?q="+url.QueryEscape("가야"), nil), http.StatusOK, &sug)
+	if len(sug.Suggestions) == 0 || sug.Suggestions[0].Text != "가야밀면" {
+		t.Fatalf("suggestions = %+v", sug.Suggestions)
+	}
+}
+
+func TestRateAndReview(t *testing.T) {
+	r, db := newTestServer(t)
+	tc := newTestClient(t, r)
+
+	rate := map[string]any{"restaurant_id": 1, "score": 4.5, "review": "국물이 시원해요", "pros": []string{"가성비"}}
+	expectError(t, tc.do(http.MethodPost, "/api/v1/rate", rate), http.StatusUnauthorized, "login_required")
+
+	tc.login(1001)
+	var result RateResult
+	decodeEnvelope(t, tc.do(http.MethodPost, "/api/v1/rate", rate), http.StatusOK, &result)
+	if result.NewAvg != 4.5 || result.RatingCount != 1 {
+		t.Fatalf("rate = %+v", result)
+	}
+
+	env := expectError(t, tc.do(http.MethodPost, "/api/v1/rate?lang=en", map[string]any{"restaurant_id": 1, "score": 4.2}), http.StatusBadRequest, "invalid_input")
+	if env.Error.Fields["score"] == "" {
+		t.Fatalf("fields = %v", env.Error.Fields)
+	}
+	env = expectError(t, tc.do(http.MethodPost, "/api/v1/rate", map[string]any{"restaurant_id": 99, "score": 3}), http.StatusBadRequest, "invalid_input")
+	if got := env.Error.Fields["restaurant_id"]; got != "존재하지 않는 식당입니다." {
+		t.Fatalf("restaurant_id message = %q", got)
+	}
+
+	var page ReviewPage
+	decodeEnvelope(t, tc.do(http.MethodGet, "/api/v1/restaurants/1/reviews", nil), http.StatusOK, &page)
+	if page.Total != 1 || page.Reviews[0].Body != "국물이 시원해요" || page.Reviews[0].Nickname != "사용자1001" {
+		t.Fatalf("reviews = %+v", page)
+	}
+	reviewPath := fmt.Sprintf("/api/v1/reviews/%d", page.Reviews[0].ID)
+
+	// 보내지 않은 필드(pros)는 그대로 유지
+	var edited store.Review
+	decodeEnvelope(t, tc.do(http.MethodPut, reviewPath, map[string]any{"review": "면이 쫄깃해요"}), http.StatusOK, &edited)
+	if edited.Body != "면이 쫄깃해요" || len(edited.Pros) != 1 || edited.EditedAt == nil {
+		t.Fatalf("edited = %+v", edited)
+	}
+	env = expectError(t, tc.do(http.MethodPut, reviewPath+"?lang=en", map[string]any{}), http.StatusBadRequest, "invalid_input")
+	if got := env.Error.Fields["review"]; got != fieldMessages["review.no_fields"][1] {
+		t.Fatalf("review message = %q", got)
+	}
+
+	// 다른 사용자는 남의 리뷰를 고칠 수 없음
+	other := newTestClient(t, r)
+	other.login(1002)
+	expectError(t, other.do(http.MethodPut, reviewPath, map[string]any{"review": "별로"}), http.StatusNotFound, "review_not_found")
+
+	decodeEnvelope(t, tc.do(http.MethodDelete, "/api/v1/rate?restaurant_id=1", nil), http.StatusOK, &result)
+	if result.RatingCount != 0 {
+		t.Fatalf("retract = %+v", result)
+	}
+	var res store.Restaurant
+	if err := db.First(&res, 1).Error; err != nil || res.RatingCount != 0 || res.AvgRating != 0 {
+		t.Fatalf("restaurant after retract = %+v, %v", res, err)
+	}
+	expectError(t, tc.do(http.MethodDelete, "/api/v1/rate?restaurant_id=abc", nil), http.StatusBadRequest, "invalid_input")
+}
+
+func TestAdminAuth(t *testing.T) {
+	r, _ := newTestServer(t)
+	in := map[string]any{"title": "새 식당", "addr": "경기 안양시 만안구 성결대학로 9", "food": "카페", "x": 126.93, "y": 37.38, "url": "https://place.map.kakao.com/3"}
+
+	anon := newTestClient(t, r)
+	expectError(t, anon.do(http.MethodPost, "/api/v1/admin/restaurants", in), http.StatusUnauthorized, "login_required")
+
+	user := newTestClient(t, r)
+	user.login(1001)
+	expectError(t, user.do(http.MethodPost, "/api/v1/admin/restaurants", in), http.StatusForbidden, "admin_required")
+
+	admin := newTestClient(t, r)
+	admin.login(testAdminKakaoID)
+	searchNew := "/api/v1/restaurants?search=" + url.QueryEscape("새식당") // 띄어쓰기가 달라 초성/오타 허용 검색으로 찾음
+	var page RestaurantPage
+	decodeEnvelope(t, admin.do(http.MethodGet, searchNew, nil), http.StatusOK, &page)
+	if page.Total != 0 {
+		t.Fatalf("search before create = %+v", page)
+	}
+
+	var created store.Restaurant
+	decodeEnvelope(t, admin.do(http.MethodPost, "/api/v1/admin/restaurants", in), http.StatusCreated, &created)
+	if created.ID == 0 || created.Title != "새 식당" {
+		t.Fatalf("created = %+v", created)
+	}
+
+	// 새 식당도 바로 검색됨 (검색 후보 캐시 갱신)
+	decodeEnvelope(t, admin.do(http.MethodGet, searchNew, nil), http.StatusOK, &page)
+	if page.Total != 1 || page.Restaurants[0].ID != created.ID {
+		t.Fatalf("search new restaurant = %+v", page)
+	}
+
+	dup := map[string]any{"title": "", "addr": "경기 안양시", "x": 126.93, "y": 37.38, "url": "https://place.map.kakao.com/3"}
+	env := expectError(t, admin.do(http.MethodPost, "/api/v1/admin/restaurants?lang=en", dup), http.StatusBadRequest, "invalid_input")
+	if got := env.Error.Fields["title"]; got != "Title must be 1-100 characters." {
+		t.Fatalf("title message = %q", got)
+	}
+	dup["title"] = "다른 식당"
+	env = expectError(t, admin.do(http.MethodPost, "/api/v1/admin/restaurants", dup), http.StatusConflict, "duplicate_place_id")
+	if got := env.Error.Fields["url"]; got != "같은 카카오 장소 URL을 쓰는 식당이 있습니다." {
+		t.Fatalf("url message = %q", got)
+	}
+
+	admin.do(http.MethodGet, "/logout", nil)
+	expectError(t, admin.do(http.MethodDelete, fmt.Sprintf("/api/v1/admin/restaurants/%d", created.ID), nil), http.StatusUnauthorized, "login_required")
+}
+
+func TestErrorEnvelope(t *testing.T) {
+	r, _ := newTestServer(t)
+	tc := newTestClient(t, r)
+
+	env := expectError(t, tc.do(http.MethodGet, "/api/v1/no-such-route", nil), http.StatusNotFound, "route_not_found")
+	if env.Error.Message != apiMessages["route_not_found"].Ko {
+		t.Fatalf("message = %q", env.Error.Message)
+	}
+	env = expectError(t, tc.do(http.MethodGet, "/api/v1/restaurants/99/menu?lang=en", nil), http.StatusNotFound, "restaurant_not_found")
+	if env.Error.Message != apiMessages["restaurant_not_found"].En {
+		t.Fatalf("message = %q", env.Error.Message)
+	}
+
+	// 스키마 검사 오류도 같은 봉투
+	env = expectError(t, tc.do(http.MethodGet, "/api/v1/restaurants?limit=abc", nil), http.StatusBadRequest, "invalid_input")
+	if env.Error.Fields["limit"] == "" {
+		t.Fatalf("fields = %v", env.Error.Fields)
+	}
+
+	// 구버전 /api: 봉투 없이 한국어 메시지, Deprecation 헤더
+	w := tc.do(http.MethodGet, "/api/restaurants/99/menu?lang=en", nil)
+	var legacy struct {
+		Error string `json:"error"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &legacy); err != nil || w.Code != http.StatusNotFound || legacy.Error != apiMessages["restaurant_not_found"].Ko {
+		t.Fatalf("legacy error = %d %s", w.Code, w.Body)
+	}
+	if w.Header().Get("Deprecation") != "true" {
+		t.Fatalf("Deprecation header = %q", w.Header().Get("Deprecation"))
+	}
+	var list []any
+	w = tc.do(http.MethodGet, "/api/categories", nil)
+	if err := json.Unmarshal(w.Body.Bytes(), &struct {
+		Categories *[]any `json:"categories"`
+	}{&list}); err != nil || len(list) == 0 {
+		t.Fatalf("legacy success = %d %s", w.Code, w.Body)
+	}
+}
+
+func TestCreateRoomBody(t *testing.T) {
+	r, _ := newTestServer(t)
+	tc := newTestClient(t, r)
+	tc.login(1001)
+
+	var room store.RoomState
+	decodeEnvelope(t, tc.doRaw(http.MethodPost, "/api/v1/rooms", "", ""), http.StatusCreated, &room)
+	if room.Code == "" || room.Title == "" {
+		t.Fatalf("room without body = %+v", room)
+	}
+	decodeEnvelope(t, tc.do(http.MethodPost, "/api/v1/rooms", map[string]any{"title": "점심"}), http.StatusCreated, &room)
+	if room.Title != "점심" {
+		t.Fatalf("room title = %q", room.Title)
+	}
+
+	for _, contentType := range []string{"application/json", ""} {
+		expectError(t, tc.doRaw(http.MethodPost, "/api/v1/rooms", contentType, `{"title": "점심"`), http.StatusBadRequest, "bad_request")
+	}
+}
+
+func TestRoomEventsStopOnShutdown(t *testing.T) {
+	_, db := newTestServer(t)
+	ctx, shutdown := context.WithCancel(context.Background())
+	defer shutdown()
+	tc := newTestClient(t, newTestRouter(ctx, db))
+	tc.login(1001)
+
+	var room store.RoomState
+	decodeEnvelope(t, tc.do(http.MethodPost, "/api/v1/rooms", nil), http.StatusCreated, &room)
+
+	done := make(chan *httptest.ResponseRecorder)
+	go func() {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/rooms/"+room.Code+"/events", nil)
+		for _, ck := range tc.cookies {
+			req.AddCookie(ck)
+		}
+		w := httptest.NewRecorder()
+		tc.r.ServeHTTP(w, req)
+		done <- w
+	}()
+
+	select {
+	case w := <-done:
+		t.Fatalf("stream ended before shutdown: %d %s", w.Code, w.Body)
+	case <-time.After(100 * time.Millisecond):
+	}
+	shutdown()
+	select {
+	case w := <-done:
+		if !strings.Contains(w.Body.String(), "event:state") {
+			t.Fatalf("stream body = %s", w.Body)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("stream did not stop on shutdown")
+	}
+}
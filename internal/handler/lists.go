@@ -0,0 +1,220 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"restaurant-api/internal/store"
+)
+
+// ListItemRequest: 목록에 식당 추가 요청
+type ListItemRequest struct {
+	RestaurantID uint `json:"restaurant_id" required:"true" minimum:"1"`
+}
+
+// ListOrderRequest: 목록 순서 변경 요청 (담긴 식당 ID 전체)
+type ListOrderRequest struct {
+	RestaurantIDs []uint `json:"restaurant_ids" required:"true"`
+}
+
+// requireLogin: 로그인한 사용자만 통과시키는 미들웨어
+func requireLogin() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if _, ok := sessionUserID(c); !ok {
+			fail(c, "login_required")
+			return
+		}
+		c.Next()
+	}
+}
+
+// listRoutes: 즐겨찾기/목록 API (공유 목록만 로그인 불필요)
+func (h *Handler) listRoutes(api, me apiRoutes) {
+	// 즐겨찾기한 식당 (최근 추가한 순)
+	me.GET("/favorites", h.listFavorites)
+
+	// 즐겨찾기 추가 (이미 있으면 그대로)
+	me.PUT("/favorites/:restaurant_id", h.addFavorite)
+
+	// 즐겨찾기 해제
+	me.DELETE("/favorites/:restaurant_id", h.removeFavorite)
+
+	// 내 목록
+	me.GET("/lists", h.myLists)
+
+	// 목록 만들기
+	me.POST("/lists", h.createList)
+
+	// 링크로 공유된 목록 (로그인 불필요)
+	api.GET("/lists/shared/:token", h.sharedList)
+
+	// 내 목록과 담긴 식당
+	me.GET("/lists/:id", h.getList)
+
+	// 목록 이름/공유 여부 수정
+	me.PUT("/lists/:id", h.updateList)
+
+	// 목록 삭제
+	me.DELETE("/lists/:id", h.deleteList)
+
+	// 목록에 식당 추가 (맨 뒤)
+	me.POST("/lists/:id/items", h.addListItem)
+
+	// 목록에서 식당 제거
+	me.DELETE("/lists/:id/items/:restaurant_id", h.removeListItem)
+
+	// 목록 순서 변경 (담긴 식당 ID 전체를 원하는 순서로)
+	me.PUT("/lists/:id/order", h.reorderList)
+}
+
+func (h *Handler) listFavorites(c *gin.Context) {
+	userID, _ := sessionUserID(c)
+	list, err := store.ListFavorites(h.db, userID)
+	if err != nil {
+		respondError(c, err, "즐겨찾기 조회")
+		return
+	}
+	respond(c, http.StatusOK, gin.H{"restaurants": list})
+}
+
+func (h *Handler) addFavorite(c *gin.Context) {
+	userID, _ := sessionUserID(c)
+	if err := store.AddFavorite(h.db, userID, paramID(c, "restaurant_id")); err != nil {
+		respondError(c, err, "즐겨찾기 추가")
+		return
+	}
+	respondMessage(c, "favorite_added")
+}
+
+func (h *Handler) removeFavorite(c *gin.Context) {
+	userID, _ := sessionUserID(c)
+	if err := store.RemoveFavorite(h.db, userID, paramID(c, "restaurant_id")); err != nil {
+		respondError(c, err, "즐겨찾기 해제")
+		return
+	}
+	respondMessage(c, "favorite_removed")
+}
+
+func (h *Handler) myLists(c *gin.Context) {
+	userID, _ := sessionUserID(c)
+	lists, err := store.MyLists(h.db, userID)
+	if err != nil {
+		respondError(c, err, "목록 조회")
+		return
+	}
+	respond(c, http.StatusOK, gin.H{"lists": lists})
+}
+
+func (h *Handler) createList(c *gin.Context) {
+	userID, _ := sessionUserID(c)
+	var in store.ListInput
+	if err := c.ShouldBindJSON(&in); err != nil {
+		fail(c, "bad_request")
+		return
+	}
+	if errs := in.Validate(); len(errs) > 0 {
+		failFields(c, "invalid_input", errs)
+		return
+	}
+
+	list, err := store.CreateList(h.db, userID, in)
+	if err != nil {
+		respondError(c, err, "목록 생성")
+		return
+	}
+	respond(c, http.StatusCreated, list)
+}
+
+func (h *Handler) sharedList(c *gin.Context) {
+	detail, err := store.SharedList(h.db, c.Param("token"))
+	if err != nil {
+		respondError(c, err, "공유 목록 조회")
+		return
+	}
+	detail.ShareToken = ""
+	respond(c, http.StatusOK, detail)
+}
+
+func (h *Handler) getList(c *gin.Context) {
+	userID, _ := sessionUserID(c)
+	list, err := store.FindOwnList(h.db, paramID(c, "id"), userID)
+	if err != nil {
+		respondError(c, err, "목록 조회")
+		return
+	}
+	detail, err := store.LoadListDetail(h.db, list)
+	if err != nil {
+		respondError(c, err, "목록 조회")
+		return
+	}
+	respond(c, http.StatusOK, detail)
+}
+
+func (h *Handler) updateList(c *gin.Context) {
+	userID, _ := sessionUserID(c)
+	var in store.ListInput
+	if err := c.ShouldBindJSON(&in); err != nil {
+		fail(c, "bad_request")
+		return
+	}
+	if errs := in.Validate(); len(errs) > 0 {
+		failFields(c, "invalid_input", errs)
+		return
+	}
+
+	list, err := store.UpdateList(h.db, paramID(c, "id"), userID, in)
+	if err != nil {
+		respondError(c, err, "목록 수정")
+		return
+	}
+	respond(c, http.StatusOK, list)
+}
+
+func (h *Handler) deleteList(c *gin.Context) {
+	userID, _ := sessionUserID(c)
+	if err := store.DeleteList(h.db, paramID(c, "id"), userID); err != nil {
+		respondError(c, err, "목록 삭제")
+		return
+	}
+	respondMessage(c, "list_deleted")
+}
+
+func (h *Handler) addListItem(c *gin.Context) {
+	userID, _ := sessionUserID(c)
+	var req ListItemRequest
+	if err := c.ShouldBindJSON(&req); err != nil || req.RestaurantID == 0 {
+		failFields(c, "invalid_input", map[string]string{"restaurant_id": "restaurant_id.required"})
+		return
+	}
+
+	if err := store.AddListItem(h.db, paramID(c, "id"), userID, req.RestaurantID); err != nil {
+		respondError(c, err, "목록 항목 추가")
+		return
+	}
+	respondMessage(c, "list_item_added")
+}
+
+func (h *Handler) removeListItem(c *gin.Context) {
+	userID, _ := sessionUserID(c)
+	if err := store.RemoveListItem(h.db, paramID(c, "id"), userID, paramID(c, "restaurant_id")); err != nil {
+		respondError(c, err, "목록 항목 삭제")
+		return
+	}
+	respondMessage(c, "list_item_removed")
+}
+
+func (h *Handler) reorderList(c *gin.Context) {
+	userID, _ := sessionUserID(c)
+	var req ListOrderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		failFields(c, "invalid_input", map[string]string{"restaurant_ids": "restaurant_ids.required"})
+		return
+	}
+
+	if err := store.ReorderList(h.db, paramID(c, "id"), userID, req.RestaurantIDs); err != nil {
+		respondError(c, err, "목록 순서 변경")
+		return
+	}
+	respondMessage(c, "list_reordered")
+}
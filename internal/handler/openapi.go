@@ -0,0 +1,755 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"restaurant-api/internal/store"
+)
+
+// OpenAPI 3 문서. 요청/응답 스키마는 Go 타입에서 reflect로 만들고, 같은 문서로 들어오는 요청
+// (경로/쿼리 파라미터, JSON/폼 본문)을 검사한다. GET /api/openapi.json, 문서 화면은 GET /api/docs
+//
+// 스키마에 쓰는 구조체 태그
+//
+//	json, form, query: 필드 이름 (form/query 태그가 없는 필드는 건너뜀)
+//	required:"true", minimum:"1", maximum:"5", multipleOf:"0.5", maxLength:"30", enum:"a,b", description:"..."
+
+// apiOperation: 문서에 싣는 API 하나 (Path는 gin 형식, /api/v1 기준)
+type apiOperation struct {
+	Method   string
+	Path     string
+	Tag      string
+	Summary  string
+	Auth     string // "", "login", "admin"
+	Query    any    // 쿼리 파라미터 구조체 (query 태그)
+	Form     any    // application/x-www-form-urlencoded 본문 구조체 (form 태그)
+	Body     any    // application/json 본문 타입 (Form과 함께 있으면 Content-Type으로 고름)
+	Status   int    // 성공 상태 코드 (기본 200)
+	Response any    // 성공 시 data 타입
+	Stream   bool   // Server-Sent Events 응답
+}
+
+// 목록/추천 공통 필터 쿼리
+type filterQuery struct {
+	Category  string  `query:"category" description:"카테고리 이름 (쉼표로 여러 개, all은 전체)"`
+	Search    string  `query:"search" description:"검색어 (이름, 음식 종류, 주소, 메뉴. 초성/오타 허용)"`
+	Lat       float64 `query:"lat" minimum:"-90" maximum:"90" description:"기준 위도 (lng와 함께)"`
+	Lng       float64 `query:"lng" minimum:"-180" maximum:"180" description:"기준 경도 (lat과 함께)"`
+	Radius    float64 `query:"radius" maximum:"5000" description:"반경 (m, lat/lng 필요)"`
+	MinRating float64 `query:"min_rating" minimum:"0" maximum:"5"`
+	Exclude   string  `query:"exclude" description:"제외할 식당 ID (쉼표로 구분)"`
+	OpenNow   bool    `query:"open_now" description:"지금 영업 중인 식당만 (open_at과 함께 쓸 수 없음)"`
+	OpenAt    string  `query:"open_at" description:"이 시각에 영업 중인 식당만 (HH:MM 또는 YYYY-MM-DDTHH:MM, 한국 시간)"`
+	MinPrice  int     `query:"min_price" minimum:"1" maximum:"1000000" description:"대표 메뉴 최저 가격 (원)"`
+	MaxPrice  int     `query:"max_price" minimum:"1" maximum:"1000000" description:"대표 메뉴 최고 가격 (원)"`
+}
+
+// 페이지 쿼리
+type pageQuery struct {
+	Limit  int    `query:"limit" minimum:"1" description:"기본 20, 최대 100"`
+	Offset int    `query:"offset" minimum:"0"`
+	Cursor string `query:"cursor" description:"이전 응답의 next_cursor (offset 대신)"`
+}
+
+type restaurantListQuery struct {
+	filterQuery
+	pageQuery
+	Sort string `query:"sort" enum:"rating,rating_count,name,distance,newest,relevance" description:"검색어가 있으면 기본은 relevance"`
+}
+
+type randomQuery struct {
+	filterQuery
+	Weight string `query:"weight" enum:"rating" description:"rating이면 별점이 높을수록 잘 뽑힘"`
+}
+
+type suggestQuery struct {
+	Q     string `query:"q" description:"입력 중인 검색어"`
+	Limit int    `query:"limit" minimum:"1" description:"기본 8, 최대 20"`
+}
+
+type reviewListQuery struct {
+	pageQuery
+	Sort string `query:"sort" enum:"newest,highest,lowest"`
+}
+
+type retractQuery struct {
+	RestaurantID uint `query:"restaurant_id" required:"true" minimum:"1"`
+}
+
+// gin.H로 보내는 응답의 모양
+type categoriesResponse struct {
+	Categories []store.CategoryCount `json:"categories"`
+}
+
+type suggestionsResponse struct {
+	Suggestions []store.Suggestion `json:"suggestions"`
+}
+
+type menuResponse struct {
+	Menu []store.MenuItem `json:"menu"`
+}
+
+type favoritesResponse struct {
+	Restaurants []store.Restaurant `json:"restaurants"`
+}
+
+type listsResponse struct {
+	Lists []store.ListSummary `json:"lists"`
+}
+
+// apiOperations: /api/v1 API 목록 (라우터에 있는데 여기 없으면 시작할 때 경고)
+var apiOperations = []apiOperation{
+	{Method: "GET", Path: "/restaurants", Tag: "식당", Summary: "맛집 목록 (필터, 정렬, 페이지, 검색 하이라이트)", Query: restaurantListQuery{}, Response: RestaurantPage{}},
+	{Method: "GET", Path: "/restaurants/random", Tag: "식당", Summary: "조건에 맞는 식당 무작위 추천", Query: randomQuery{}, Response: store.RestaurantResult{}},
+	{Method: "GET", Path: "/categories", Tag: "식당", Summary: "카테고리와 카테고리별 식당 수", Response: categoriesResponse{}},
+	{Method: "GET", Path: "/search/suggest", Tag: "식당", Summary: "검색어 자동완성 (초성/오타 허용)", Query: suggestQuery{}, Response: suggestionsResponse{}},
+	{Method: "GET", Path: "/restaurants/:id/menu", Tag: "식당", Summary: "식당 메뉴", Response: menuResponse{}},
+
+	{Method: "POST", Path: "/rate", Tag: "별점/리뷰", Summary: "별점 등록 또는 재평가 (리뷰 함께 저장 가능)", Auth: "login", Body: RateRequest{}, Form: RateRequest{}, Response: RateResult{}},
+	{Method: "PUT", Path: "/rate", Tag: "별점/리뷰", Summary: "기존 별점 수정", Auth: "login", Body: RateRequest{}, Form: RateRequest{}, Response: RateResult{}},
+	{Method: "DELETE", Path: "/rate", Tag: "별점/리뷰", Summary: "별점 철회", Auth: "login", Query: retractQuery{}, Response: RateResult{}},
+	{Method: "GET", Path: "/restaurants/:id/reviews", Tag: "별점/리뷰", Summary: "식당 리뷰 목록", Query: reviewListQuery{}, Response: ReviewPage{}},
+	{Method: "PUT", Path: "/reviews/:id", Tag: "별점/리뷰", Summary: "내 리뷰 수정", Auth: "login", Body: ReviewForm{}, Form: ReviewForm{}, Response: store.Review{}},
+	{Method: "DELETE", Path: "/reviews/:id", Tag: "별점/리뷰", Summary: "내 리뷰 삭제 (별점은 유지)", Auth: "login", Response: Message{}},
+
+	{Method: "GET", Path: "/favorites", Tag: "즐겨찾기/목록", Summary: "즐겨찾기한 식당", Auth: "login", Response: favoritesResponse{}},
+	{Method: "PUT", Path: "/favorites/:restaurant_id", Tag: "즐겨찾기/목록", Summary: "즐겨찾기 추가", Auth: "login", Response: Message{}},
+	{Method: "DELETE", Path: "/favorites/:restaurant_id", Tag: "즐겨찾기/목록", Summary: "즐겨찾기 해제", Auth: "login", Response: Message{}},
+	{Method: "GET", Path: "/lists", Tag: "즐겨찾기/목록", Summary: "내 목록", Auth: "login", Response: listsResponse{}},
+	{Method: "POST", Path: "/lists", Tag: "즐겨찾기/목록", Summary: "목록 만들기", Auth: "login", Body: store.ListInput{}, Status: http.StatusCreated, Response: store.RestaurantList{}},
+	{Method: "GET", Path: "/lists/shared/:token", Tag: "즐겨찾기/목록", Summary: "링크로 공유된 목록", Response: store.ListDetail{}},
+	{Method: "GET", Path: "/lists/:id", Tag: "즐겨찾기/목록", Summary: "내 목록과 담긴 식당", Auth: "login", Response: store.ListDetail{}},
+	{Method: "PUT", Path: "/lists/:id", Tag: "즐겨찾기/목록", Summary: "목록 이름/공유 여부 수정", Auth: "login", Body: store.ListInput{}, Response: store.RestaurantList{}},
+	{Method: "DELETE", Path: "/lists/:id", Tag: "즐겨찾기/목록", Summary: "목록 삭제", Auth: "login", Response: Message{}},
+	{Method: "POST", Path: "/lists/:id/items", Tag: "즐겨찾기/목록", Summary: "목록에 식당 추가", Auth: "login", Body: ListItemRequest{}, Response: Message{}},
+	{Method: "DELETE", Path: "/lists/:id/items/:restaurant_id", Tag: "즐겨찾기/목록", Summary: "목록에서 식당 제거", Auth: "login", Response: Message{}},
+	{Method: "PUT", Path: "/lists/:id/order", Tag: "즐겨찾기/목록", Summary: "목록 순서 변경", Auth: "login", Body: ListOrderRequest{}, Response: Message{}},
+
+	{Method: "POST", Path: "/rooms", Tag: "같이 먹기", Summary: "방 만들기", Auth: "login", Body: RoomRequest{}, Status: http.StatusCreated, Response: store.RoomState{}},
+	{Method: "POST", Path: "/rooms/:code/join", Tag: "같이 먹기", Summary: "코드로 방 참여", Auth: "login", Response: store.RoomState{}},
+	{Method: "GET", Path: "/rooms/:code", Tag: "같이 먹기", Summary: "방 상태", Auth: "login", Response: store.RoomState{}},
+	{Method: "POST", Path: "/rooms/:code/candidates", Tag: "같이 먹기", Summary: "후보 식당 제안", Auth: "login", Body: CandidateRequest{}, Response: store.RoomState{}},
+	{Method: "PUT", Path: "/rooms/:code/vote", Tag: "같이 먹기", Summary: "투표", Auth: "login", Body: VoteRequest{}, Response: store.RoomState{}},
+	{Method: "POST", Path: "/rooms/:code/close", Tag: "같이 먹기", Summary: "투표 마감 (방장만)", Auth: "login", Response: store.RoomState{}},
+	{Method: "GET", Path: "/rooms/:code/events", Tag: "같이 먹기", Summary: "실시간 알림 (state, result, ping 이벤트)", Auth: "login", Stream: true},
+
+	{Method: "POST", Path: "/admin/restaurants", Tag: "관리자", Summary: "식당 등록", Auth: "admin", Body: store.RestaurantInput{}, Status: http.StatusCreated, Response: store.Restaurant{}},
+	{Method: "PUT", Path: "/admin/restaurants/:id", Tag: "관리자", Summary: "식당 수정", Auth: "admin", Body: store.RestaurantInput{}, Response: store.Restaurant{}},
+	{Method: "DELETE", Path: "/admin/restaurants/:id", Tag: "관리자", Summary: "식당 삭제 (소프트 삭제)", Auth: "admin", Response: Message{}},
+	{Method: "POST", Path: "/admin/restaurants/:id/restore", Tag: "관리자", Summary: "삭제된 식당 복구", Auth: "admin", Response: store.Restaurant{}},
+	{Method: "PUT", Path: "/admin/restaurants/:id/hours", Tag: "관리자", Summary: "영업시간/임시 휴무일 설정", Auth: "admin", Body: store.HoursInput{}, Response: store.Restaurant{}},
+	{Method: "POST", Path: "/admin/restaurants/:id/menu", Tag: "관리자", Summary: "메뉴 추가", Auth: "admin", Body: store.MenuItemInput{}, Status: http.StatusCreated, Response: store.MenuItem{}},
+	{Method: "PUT", Path: "/admin/menu/:id", Tag: "관리자", Summary: "메뉴 수정", Auth: "admin", Body: store.MenuItemInput{}, Response: store.MenuItem{}},
+	{Method: "DELETE", Path: "/admin/menu/:id", Tag: "관리자", Summary: "메뉴 삭제", Auth: "admin", Response: Message{}},
+	{Method: "GET", Path: "/admin/reports/data-quality", Tag: "관리자", Summary: "데이터 점검", Auth: "admin", Response: store.QualityReport{}},
+}
+
+// 요청과 상관없이 문서에 싣는 모델
+var openAPIModels = []any{store.Restaurant{}, store.Rating{}}
+
+var (
+	timeType      = reflect.TypeOf(time.Time{})
+	deletedAtType = reflect.TypeOf(gorm.DeletedAt{})
+)
+
+// schemaField: 구조체 필드 하나의 이름과 스키마
+type schemaField struct {
+	Name     string
+	Schema   map[string]any
+	Required bool
+}
+
+// openAPIOperation: 요청 검사에 쓰는 API 하나의 파라미터/본문 스키마
+type openAPIOperation struct {
+	path  []schemaField
+	query []schemaField
+	form  []schemaField
+	body  map[string]any
+}
+
+// openAPI: 만든 문서와 요청 검사용 정보
+type openAPI struct {
+	doc        map[string]any
+	schemas    map[string]any               // components/schemas
+	operations map[string]*openAPIOperation // "GET /lists/:id"
+}
+
+// newOpenAPI: apiOperations로 문서를 만듦
+func newOpenAPI() *openAPI {
+	o := &openAPI{schemas: map[string]any{}, operations: map[string]*openAPIOperation{}}
+	for _, model := range openAPIModels {
+		o.schema(reflect.TypeOf(model))
+	}
+
+	paths := map[string]any{}
+	for _, op := range apiOperations {
+		path := openAPIPath(op.Path)
+		item, ok := paths[path].(map[string]any)
+		if !ok {
+			item = map[string]any{}
+			paths[path] = item
+		}
+		item[strings.ToLower(op.Method)] = o.operation(op)
+	}
+
+	codes := []string{}
+	for code, m := range apiMessages {
+		if m.Status != 0 {
+			codes = append(codes, code)
+		}
+	}
+	sort.Strings(codes)
+	o.schemas["Error"] = map[string]any{
+		"type":     "object",
+		"required": []string{"error"},
+		"properties": map[string]any{
+			"error": map[string]any{
+				"type":     "object",
+				"required": []string{"code", "message"},
+				"properties": map[string]any{
+					"code":    map[string]any{"type": "string", "enum": codes},
+					"message": map[string]any{"type": "string", "description": "사용자에게 보여 줄 메시지 (?lang=en이면 영어)"},
+					"fields":  map[string]any{"type": "object", "additionalProperties": map[string]any{"type": "string"}, "description": "필드별 오류 메시지"},
+				},
+			},
+		},
+	}
+
+	o.doc = map[string]any{
+		"openapi": "3.0.3",
+		"info": map[string]any{
+			"title":       "모먹지 API",
+			"version":     "1.0.0",
+			"description": "성결대 맛집 가이드 API. 성공 응답은 {\"data\": ...}, 실패 응답은 {\"error\": {\"code\", \"message\", \"fields\"}} 형식입니다.",
+		},
+		"servers": []any{map[string]any{"url": "/api/v1"}},
+		"paths":   paths,
+		"components": map[string]any{
+			"schemas": o.schemas,
+			"responses": map[string]any{
+				"Error": map[string]any{
+					"description": "오류",
+					"content":     map[string]any{"application/json": map[string]any{"schema": schemaRef("Error")}},
+				},
+			},
+			"securitySchemes": map[string]any{
+				"session": map[string]any{"type": "apiKey", "in": "cookie", "name": sessionName, "description": "카카오 로그인 후 발급되는 세션 쿠키"},
+			},
+		},
+	}
+	return o
+}
+
+// openAPIPath: gin 경로를 OpenAPI 경로로 (/lists/:id → /lists/{id})
+func openAPIPath(path string) string {
+	parts := strings.Split(path, "/")
+	for i, p := range parts {
+		if strings.HasPrefix(p, ":") {
+			parts[i] = "{" + p[1:] + "}"
+		}
+	}
+	return strings.Join(parts, "/")
+}
+
+func schemaRef(name string) map[string]any {
+	return map[string]any{"$ref": "#/components/schemas/" + name}
+}
+
+// operation: API 하나의 문서 (검사용 정보도 함께 저장)
+func (o *openAPI) operation(op apiOperation) map[string]any {
+	check := &openAPIOperation{}
+	var params []any
+	for _, p := range strings.Split(op.Path, "/") {
+		if !strings.HasPrefix(p, ":") {
+			continue
+		}
+		name := p[1:]
+		s := map[string]any{"type": "string"}
+		if name == "id" || strings.HasSuffix(name, "_id") {
+			s = map[string]any{"type": "integer", "minimum": 1}
+		}
+		check.path = append(check.path, schemaField{Name: name, Schema: s, Required: true})
+		params = append(params, map[string]any{"name": name, "in": "path", "required": true, "schema": s})
+	}
+	if op.Query != nil {
+		check.query = o.fields(reflect.TypeOf(op.Query), "query")
+		for _, f := range check.query {
+			param := map[string]any{"name": f.Name, "in": "query", "required": f.Required, "schema": f.Schema}
+			if d, ok := f.Schema["description"]; ok {
+				param["description"] = d
+			}
+			params = append(params, param)
+		}
+	}
+
+	doc := map[string]any{"tags": []string{op.Tag}, "summary": op.Summary}
+	if len(params) > 0 {
+		doc["parameters"] = params
+	}
+	content := map[string]any{}
+	if op.Body != nil {
+		check.body = o.schema(reflect.TypeOf(op.Body))
+		content["application/json"] = map[string]any{"schema": check.body}
+	}
+	if op.Form != nil {
+		check.form = o.fields(reflect.TypeOf(op.Form), "form")
+		content["application/x-www-form-urlencoded"] = map[string]any{"schema": objectSchema(check.form)}
+	}
+	if len(content) > 0 {
+		doc["requestBody"] = map[string]any{"required": true, "content": content}
+	}
+	switch op.Auth {
+	case "login":
+		doc["security"] = []any{map[string]any{"session": []string{}}}
+	case "admin":
+		doc["security"] = []any{map[string]any{"session": []string{}}}
+		doc["description"] = "관리자(ADMIN_KAKAO_IDS 또는 role=admin)만 호출할 수 있습니다."
+	}
+
+	status := op.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	success := map[string]any{"description": http.StatusText(status)}
+	if op.Stream {
+		success["content"] = map[string]any{"text/event-stream": map[string]any{"schema": map[string]any{"type": "string"}}}
+	} else {
+		success["content"] = map[string]any{"application/json": map[string]any{"schema": map[string]any{
+			"type":       "object",
+			"required":   []string{"data"},
+			"properties": map[string]any{"data": o.schema(reflect.TypeOf(op.Response))},
+		}}}
+	}
+	doc["responses"] = map[string]any{
+		strconv.Itoa(status): success,
+		"default":            map[string]any{"$ref": "#/components/responses/Error"},
+	}
+
+	o.operations[op.Method+" "+op.Path] = check
+	return doc
+}
+
+// schema: Go 타입의 JSON 스키마 (이름 있는 공개 구조체는 components/schemas로 분리)
+func (o *openAPI) schema(t reflect.Type) map[string]any {
+	switch t {
+	case timeType:
+		return map[string]any{"type": "string", "format": "date-time"}
+	case deletedAtType:
+		return map[string]any{"type": "string", "format": "date-time", "nullable": true}
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		s := o.schema(t.Elem())
+		if _, ok := s["$ref"]; ok {
+			return map[string]any{"allOf": []any{s}, "nullable": true}
+		}
+		s["nullable"] = true
+		return s
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return map[string]any{"type": "integer"}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer", "minimum": 0}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Slice, reflect.Array:
+		return map[string]any{"type": "array", "items": o.schema(t.Elem())}
+	case reflect.Map:
+		return map[string]any{"type": "object", "additionalProperties": o.schema(t.Elem())}
+	case reflect.Struct:
+		name := t.Name()
+		if r, _ := utf8.DecodeRuneInString(name); !unicode.IsUpper(r) {
+			return objectSchema(o.fields(t, "json")) // 익명/비공개 구조체는 그 자리에 펼침
+		}
+		if _, ok := o.schemas[name]; !ok {
+			o.schemas[name] = nil // 자기 참조 타입에서 무한 반복 방지
+			o.schemas[name] = objectSchema(o.fields(t, "json"))
+		}
+		return schemaRef(name)
+	}
+	return map[string]any{}
+}
+
+// fields: 구조체 필드를 태그(json/form/query) 이름 순서대로 모음. 태그 없이 포함된 구조체는 펼친다
+func (o *openAPI) fields(t reflect.Type, tag string) []schemaField {
+	var fields []schemaField
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, _, _ := strings.Cut(f.Tag.Get(tag), ",")
+		if name == "-" {
+			continue
+		}
+		if f.Anonymous && name == "" {
+			ft := f.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct && ft != timeType {
+				fields = append(fields, o.fields(ft, tag)...)
+				continue
+			}
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			if tag != "json" {
+				continue // 폼/쿼리는 태그가 있는 필드만
+			}
+			name = f.Name
+		}
+
+		s := o.schema(f.Type)
+		if _, isRef := s["$ref"]; !isRef {
+			applySchemaTags(s, f.Tag)
+		}
+		fields = append(fields, schemaField{Name: name, Schema: s, Required: f.Tag.Get("required") == "true"})
+	}
+	return fields
+}
+
+// applySchemaTags: minimum/maximum/maxLength/enum/description 태그 반영
+func applySchemaTags(s map[string]any, tag reflect.StructTag) {
+	for _, key := range []string{"minimum", "maximum"} {
+		if v, err := strconv.ParseFloat(tag.Get(key), 64); err == nil {
+			s[key] = v
+		}
+	}
+	if v, err := strconv.ParseFloat(tag.Get("multipleOf"), 64); err == nil && v > 0 {
+		s["multipleOf"] = v
+	}
+	if v, err := strconv.Atoi(tag.Get("maxLength")); err == nil {
+		s["maxLength"] = v
+	}
+	if v := tag.Get("enum"); v != "" {
+		s["enum"] = strings.Split(v, ",")
+	}
+	if v := tag.Get("description"); v != "" {
+		s["description"] = v
+	}
+}
+
+// objectSchema: 필드 목록으로 object 스키마
+func objectSchema(fields []schemaField) map[string]any {
+	props := map[string]any{}
+	required := []string{}
+	for _, f := range fields {
+		props[f.Name] = f.Schema
+		if f.Required {
+			required = append(required, f.Name)
+		}
+	}
+	s := map[string]any{"type": "object", "properties": props}
+	if len(required) > 0 {
+		s["required"] = required
+	}
+	return s
+}
+
+// checkRoutes: 라우터의 /api/v1 경로와 문서를 비교해 빠진 것을 경고
+func (o *openAPI) checkRoutes(routes gin.RoutesInfo) {
+	registered := map[string]bool{}
+	for _, route := range routes {
+		path, ok := strings.CutPrefix(route.Path, "/api/v1")
+		if !ok {
+			continue
+		}
+		key := route.Method + " " + path
+		registered[key] = true
+		if _, ok := o.operations[key]; !ok {
+			log.Println("WARN  API 문서에 없는 경로:", route.Method, route.Path)
+		}
+	}
+	for key := range o.operations {
+		if !registered[key] {
+			log.Println("WARN  API 문서에만 있는 경로:", key)
+		}
+	}
+}
+
+// find: 요청에 맞는 문서상의 API (/api/v1과 구버전 /api 모두)
+func (o *openAPI) find(c *gin.Context) *openAPIOperation {
+	path, ok := strings.CutPrefix(c.FullPath(), "/api/v1")
+	if !ok {
+		path = strings.TrimPrefix(c.FullPath(), "/api")
+	}
+	return o.operations[c.Request.Method+" "+path]
+}
+
+// validate: 요청을 문서와 비교하는 미들웨어. 맞지 않으면 필드별 메시지와 함께 invalid_input
+func (o *openAPI) validate() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		op := o.find(c)
+		if op == nil {
+			c.Next()
+			return
+		}
+		v := schemaValidator{api: o, lang: locale(c), errs: map[string]string{}}
+
+		for _, p := range op.path {
+			v.param(p, []string{c.Param(p.Name)})
+		}
+		query := c.Request.URL.Query()
+		for _, p := range op.query {
+			v.param(p, query[p.Name])
+		}
+
+		// 본문 형식은 JSON과 폼 중 문서에 있는 것 (둘 다 있으면 Content-Type으로)
+		useJSON := op.body != nil && (op.form == nil || c.ContentType() == gin.MIMEJSON)
+		if op.form != nil && !useJSON {
+			if err := c.Request.ParseMultipartForm(32 << 20); err != nil && err != http.ErrNotMultipart {
+				fail(c, "bad_request")
+				return
+			}
+			for _, p := range op.form {
+				v.param(p, c.Request.PostForm[p.Name])
+			}
+		}
+
+		if useJSON {
+			raw, err := io.ReadAll(c.Request.Body)
+			if err != nil {
+				fail(c, "bad_request")
+				return
+			}
+			c.Request.Body = io.NopCloser(bytes.NewReader(raw)) // 핸들러가 다시 읽을 수 있게
+			var body any = map[string]any{}
+			if len(bytes.TrimSpace(raw)) > 0 {
+				dec := json.NewDecoder(bytes.NewReader(raw))
+				dec.UseNumber()
+				if err := dec.Decode(&body); err != nil {
+					fail(c, "bad_request")
+					return
+				}
+			}
+			v.value(op.body, body, "")
+		}
+
+		if len(v.errs) > 0 {
+			failMessages(c, "invalid_input", v.errs)
+			return
+		}
+		c.Next()
+	}
+}
+
+// 스키마 검사 메시지 (ko, en)
+var schemaMessages = map[string][2]string{
+	"required":   {"필수 항목입니다.", "This field is required."},
+	"integer":    {"정수여야 합니다.", "Must be an integer."},
+	"number":     {"숫자여야 합니다.", "Must be a number."},
+	"boolean":    {"true 또는 false여야 합니다.", "Must be true or false."},
+	"string":     {"문자열이어야 합니다.", "Must be a string."},
+	"array":      {"배열이어야 합니다.", "Must be an array."},
+	"object":     {"객체여야 합니다.", "Must be an object."},
+	"minimum":    {"%s 이상이어야 합니다.", "Must be at least %s."},
+	"maximum":    {"%s 이하여야 합니다.", "Must be at most %s."},
+	"multipleOf": {"%s 단위여야 합니다.", "Must be a multiple of %s."},
+	"maxLength":  {"%s자 이하여야 합니다.", "Must be at most %s characters."},
+	"enum":       {"%s 중 하나여야 합니다.", "Must be one of %s."},
+}
+
+// schemaValidator: 요청 값 하나씩 스키마와 비교하며 필드별 오류를 모음
+type schemaValidator struct {
+	api  *openAPI
+	lang string
+	errs map[string]string
+}
+
+func (v *schemaValidator) fail(path, kind string, args ...any) {
+	if _, ok := v.errs[path]; ok {
+		return // 필드마다 첫 오류만
+	}
+	m := schemaMessages[kind]
+	msg := m[0]
+	if v.lang == "en" {
+		msg = m[1]
+	}
+	if len(args) > 0 {
+		msg = fmt.Sprintf(msg, args...)
+	}
+	v.errs[path] = msg
+}
+
+// resolve: $ref/allOf를 따라간 실제 스키마와 null 허용 여부
+func (v *schemaValidator) resolve(s map[string]any) (map[string]any, bool) {
+	nullable, _ := s["nullable"].(bool)
+	if all, ok := s["allOf"].([]any); ok && len(all) > 0 {
+		s, _ = all[0].(map[string]any)
+	}
+	if ref, ok := s["$ref"].(string); ok {
+		s, _ = v.api.schemas[strings.TrimPrefix(ref, "#/components/schemas/")].(map[string]any)
+	}
+	return s, nullable
+}
+
+// param: 경로/쿼리/폼 값 (문자열) 검사
+func (v *schemaValidator) param(p schemaField, values []string) {
+	if len(values) == 0 || (len(values) == 1 && values[0] == "") {
+		if p.Required {
+			v.fail(p.Name, "required")
+		}
+		return
+	}
+	s := p.Schema
+	if s["type"] == "array" {
+		s, _ = s["items"].(map[string]any)
+	}
+	for _, raw := range values {
+		var value any = raw
+		switch s["type"] {
+		case "integer", "number":
+			value = json.Number(raw)
+		case "boolean":
+			b, err := strconv.ParseBool(raw)
+			if err != nil {
+				v.fail(p.Name, "boolean")
+				return
+			}
+			value = b
+		}
+		v.value(s, value, p.Name)
+	}
+}
+
+// value: JSON 값 검사 (path: 오류 메시지를 붙일 필드 이름, 예: hours[0].open)
+func (v *schemaValidator) value(s map[string]any, value any, path string) {
+	s, nullable := v.resolve(s)
+	if s == nil {
+		return
+	}
+	if value == nil {
+		if !nullable {
+			v.fail(path, typeName(s))
+		}
+		return
+	}
+
+	switch s["type"] {
+	case "object":
+		obj, ok := value.(map[string]any)
+		if !ok {
+			v.fail(path, "object")
+			return
+		}
+		if required, ok := s["required"].([]string); ok {
+			for _, name := range required {
+				if _, ok := obj[name]; !ok {
+					v.fail(joinPath(path, name), "required")
+				}
+			}
+		}
+		props, _ := s["properties"].(map[string]any)
+		extra, _ := s["additionalProperties"].(map[string]any)
+		for name, fv := range obj {
+			if ps, ok := props[name].(map[string]any); ok {
+				v.value(ps, fv, joinPath(path, name))
+			} else if extra != nil {
+				v.value(extra, fv, joinPath(path, name))
+			}
+		}
+	case "array":
+		items, ok := value.([]any)
+		if !ok {
+			v.fail(path, "array")
+			return
+		}
+		is, _ := s["items"].(map[string]any)
+		for i, item := range items {
+			v.value(is, item, fmt.Sprintf("%s[%d]", path, i))
+		}
+	case "string":
+		str, ok := value.(string)
+		if !ok {
+			v.fail(path, "string")
+			return
+		}
+		if n, ok := s["maxLength"].(int); ok && utf8.RuneCountInString(str) > n {
+			v.fail(path, "maxLength", strconv.Itoa(n))
+		}
+		if enum, ok := s["enum"].([]string); ok && !containsString(enum, str) {
+			v.fail(path, "enum", strings.Join(enum, ", "))
+		}
+	case "integer", "number":
+		num, ok := value.(json.Number)
+		if !ok {
+			v.fail(path, s["type"].(string))
+			return
+		}
+		f, err := num.Float64()
+		if err != nil || (s["type"] == "integer" && f != float64(int64(f))) {
+			v.fail(path, s["type"].(string))
+			return
+		}
+		if min, ok := schemaNumber(s["minimum"]); ok && f < min {
+			v.fail(path, "minimum", formatNumber(min))
+		}
+		if max, ok := schemaNumber(s["maximum"]); ok && f > max {
+			v.fail(path, "maximum", formatNumber(max))
+		}
+		if step, ok := schemaNumber(s["multipleOf"]); ok && math.Mod(f, step) != 0 {
+			v.fail(path, "multipleOf", formatNumber(step))
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			v.fail(path, "boolean")
+		}
+	}
+}
+
+func typeName(s map[string]any) string {
+	if t, ok := s["type"].(string); ok {
+		return t
+	}
+	return "object"
+}
+
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+func schemaNumber(v any) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case int:
+		return float64(n), true
+	}
+	return 0, false
+}
+
+func formatNumber(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
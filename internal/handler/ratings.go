@@ -0,0 +1,186 @@
+package handler
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/gin-gonic/gin"
+
+	"restaurant-api/internal/store"
+)
+
+// RateRequest: 별점 평가 요청 (JSON 또는 폼). review/pros/cons가 하나라도 있으면 리뷰도 저장
+type RateRequest struct {
+	RestaurantID uint     `json:"restaurant_id" form:"restaurant_id" required:"true" minimum:"1"`
+	Score        float64  `json:"score" form:"score" required:"true" minimum:"1" maximum:"5" multipleOf:"0.5" description:"0.5점 단위"`
+	Review       *string  `json:"review" form:"review" maxLength:"1000"`
+	Pros         []string `json:"pros" form:"pros"`
+	Cons         []string `json:"cons" form:"cons"`
+}
+
+// validate: 필드별 오류 코드 (문제가 없으면 빈 맵)
+func (in *RateRequest) validate() map[string]string {
+	errs := map[string]string{}
+	if in.RestaurantID == 0 {
+		errs["restaurant_id"] = "restaurant_id.required"
+	}
+	if in.Score < 1 || in.Score > 5 || math.Mod(in.Score*2, 1) != 0 {
+		errs["score"] = "score.range"
+	}
+	if in.Review != nil && utf8.RuneCountInString(strings.TrimSpace(*in.Review)) > store.MaxReviewLength {
+		errs["review"] = "review.length"
+	}
+	return errs
+}
+
+// review: 함께 저장할 리뷰 (리뷰 필드가 하나도 없으면 nil)
+func (in RateRequest) review() *store.ReviewInput {
+	if in.Review == nil && in.Pros == nil && in.Cons == nil {
+		return nil
+	}
+	review := store.ReviewInput{Pros: in.Pros, Cons: in.Cons}
+	if in.Review != nil {
+		review.Body = *in.Review
+	}
+	return &review
+}
+
+// RateResult: 별점 평가/철회 응답
+type RateResult struct {
+	Message     string  `json:"message"`
+	NewAvg      float64 `json:"new_avg"` // 식당 평균 별점
+	RatingCount int     `json:"rating_count"`
+}
+
+// ReviewForm: 리뷰 수정 요청 (JSON 또는 폼). 보내지 않은 필드는 기존 값 유지
+type ReviewForm struct {
+	Review *string  `json:"review" form:"review" maxLength:"1000"`
+	Pros   []string `json:"pros" form:"pros"`
+	Cons   []string `json:"cons" form:"cons"`
+}
+
+// ReviewPage: 리뷰 목록 응답
+type ReviewPage struct {
+	Total      int64              `json:"total"`
+	Limit      int                `json:"limit"`
+	Offset     int                `json:"offset"`
+	NextCursor *string            `json:"next_cursor"` // 다음 페이지가 없으면 null
+	Reviews    []store.ReviewView `json:"reviews"`
+}
+
+func (h *Handler) ratingRoutes(api apiRoutes) {
+	// 별점 평가 API (POST: 등록 또는 재평가, PUT: 기존 별점 수정)
+	// JSON 또는 폼 본문. score는 1~5점, 0.5점 단위
+	api.POST("/rate", requireLogin(), h.rate(false))
+	api.PUT("/rate", requireLogin(), h.rate(true))
+
+	// 별점 철회 API
+	api.DELETE("/rate", requireLogin(), h.retractRating)
+
+	// 리뷰 목록 API (sort: newest|highest|lowest)
+	api.GET("/restaurants/:id/reviews", h.listReviews)
+
+	// 리뷰 수정 API (본인 리뷰만)
+	api.PUT("/reviews/:id", requireLogin(), h.editReview)
+
+	// 리뷰 삭제 API (본인 리뷰만, 별점은 유지)
+	api.DELETE("/reviews/:id", requireLogin(), h.deleteReview)
+}
+
+func (h *Handler) rate(requireExisting bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, _ := sessionUserID(c)
+
+		var req RateRequest
+		if err := c.ShouldBind(&req); err != nil {
+			fail(c, "bad_request")
+			return
+		}
+		if errs := req.validate(); len(errs) > 0 {
+			failFields(c, "invalid_input", errs)
+			return
+		}
+
+		res, err := store.RateRestaurant(h.db, req.RestaurantID, userID, req.Score, requireExisting, req.review())
+		if errors.Is(err, store.ErrRestaurantNotFound) {
+			failFields(c, "invalid_input", map[string]string{"restaurant_id": "restaurant_id.not_found"})
+			return
+		}
+		if err != nil {
+			respondError(c, err, "별점 저장")
+			return
+		}
+
+		respond(c, http.StatusOK, RateResult{Message: message(c, "rated"), NewAvg: res.AvgRating, RatingCount: res.RatingCount})
+	}
+}
+
+func (h *Handler) retractRating(c *gin.Context) {
+	userID, _ := sessionUserID(c)
+
+	resID, err := strconv.ParseUint(c.Query("restaurant_id"), 10, 64)
+	if err != nil || resID == 0 {
+		failFields(c, "invalid_input", map[string]string{"restaurant_id": "restaurant_id.required"})
+		return
+	}
+
+	res, err := store.RetractRating(h.db, uint(resID), userID)
+	if err != nil {
+		respondError(c, err, "별점 철회")
+		return
+	}
+
+	respond(c, http.StatusOK, RateResult{Message: message(c, "rating_retracted"), NewAvg: res.AvgRating, RatingCount: res.RatingCount})
+}
+
+func (h *Handler) listReviews(c *gin.Context) {
+	limit, offset := pageParams(c)
+
+	list, total, err := store.ListReviews(h.db, paramID(c, "id"), c.Query("sort"), limit, offset)
+	if err != nil {
+		respondError(c, err, "리뷰 조회")
+		return
+	}
+	respond(c, http.StatusOK, ReviewPage{
+		Total:      total,
+		Limit:      limit,
+		Offset:     offset,
+		NextCursor: nextCursor(offset, limit, total),
+		Reviews:    list,
+	})
+}
+
+func (h *Handler) editReview(c *gin.Context) {
+	userID, _ := sessionUserID(c)
+
+	var req ReviewForm
+	if err := c.ShouldBind(&req); err != nil {
+		fail(c, "bad_request")
+		return
+	}
+	if req.Review == nil && req.Pros == nil && req.Cons == nil {
+		failFields(c, "invalid_input", map[string]string{"review": "review.no_fields"})
+		return
+	}
+
+	review, err := store.EditReview(h.db, paramID(c, "id"), userID, store.ReviewPatch{Body: req.Review, Pros: req.Pros, Cons: req.Cons})
+	if err != nil {
+		respondError(c, err, "리뷰 수정")
+		return
+	}
+	respond(c, http.StatusOK, review)
+}
+
+func (h *Handler) deleteReview(c *gin.Context) {
+	userID, _ := sessionUserID(c)
+
+	if err := store.DeleteReview(h.db, paramID(c, "id"), userID); err != nil {
+		respondError(c, err, "리뷰 삭제")
+		return
+	}
+	respondMessage(c, "review_deleted")
+}
@@ -0,0 +1,184 @@
+package handler
+
+import (
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"restaurant-api/internal/store"
+)
+
+var (
+	errInvalidMinRating = errors.New("invalid min_rating")
+	errInvalidExclude   = errors.New("invalid exclude")
+)
+
+// RestaurantPage: 맛집 목록 응답
+type RestaurantPage struct {
+	Total       int64                    `json:"total"`
+	Limit       int                      `json:"limit"`
+	Offset      int                      `json:"offset"`
+	NextCursor  *string                  `json:"next_cursor"` // 다음 페이지가 없으면 null
+	Restaurants []store.RestaurantResult `json:"restaurants"`
+}
+
+func (h *Handler) restaurantRoutes(api apiRoutes) {
+	// 맛집 리스트 API
+	// category, search, lat/lng/radius, min_rating, exclude: 필터
+	// sort: rating|rating_count|name|distance|newest, limit/offset 또는 cursor: 페이지
+	api.GET("/restaurants", h.listRestaurants)
+
+	// 카테고리 목록 API (카테고리별 식당 수 포함)
+	api.GET("/categories", h.listCategories)
+
+	// 검색어 자동완성 API (q: 입력 중인 검색어, 초성/오타 허용, limit: 기본 8개, 최대 20개)
+	api.GET("/search/suggest", h.suggest)
+
+	// 무작위 추천 API (목록 API와 같은 필터, weight=rating: 별점 가중치)
+	api.GET("/restaurants/random", h.randomRestaurant)
+
+	// 식당 메뉴 (대표 메뉴 먼저, 가격 낮은 순)
+	api.GET("/restaurants/:id/menu", h.listMenu)
+}
+
+func (h *Handler) listRestaurants(c *gin.Context) {
+	filter, err := parseRestaurantFilter(c)
+	if err != nil {
+		respondError(c, err, "맛집 목록 조회")
+		return
+	}
+	limit, offset := pageParams(c)
+
+	list, total, err := store.ListRestaurants(h.db, filter, c.Query("sort"), limit, offset)
+	if err == nil {
+		err = store.AttachHighlights(h.db, list, filter.Search)
+	}
+	if err != nil {
+		respondError(c, err, "맛집 목록 조회")
+		return
+	}
+	respond(c, http.StatusOK, RestaurantPage{
+		Total:       total,
+		Limit:       limit,
+		Offset:      offset,
+		NextCursor:  nextCursor(offset, limit, total),
+		Restaurants: list,
+	})
+}
+
+func (h *Handler) listCategories(c *gin.Context) {
+	list, err := store.ListCategories(h.db)
+	if err != nil {
+		respondError(c, err, "카테고리 조회")
+		return
+	}
+	respond(c, http.StatusOK, gin.H{"categories": list})
+}
+
+func (h *Handler) suggest(c *gin.Context) {
+	limit, err := strconv.Atoi(c.Query("limit"))
+	if err != nil || limit <= 0 {
+		limit = 8
+	}
+	if limit > 20 {
+		limit = 20
+	}
+	list, err := store.Suggest(h.db, c.Query("q"), limit)
+	if err != nil {
+		respondError(c, err, "자동완성 조회")
+		return
+	}
+	respond(c, http.StatusOK, gin.H{"suggestions": list})
+}
+
+func (h *Handler) randomRestaurant(c *gin.Context) {
+	filter, err := parseRestaurantFilter(c)
+	if err != nil {
+		respondError(c, err, "무작위 추천")
+		return
+	}
+
+	pick, err := store.PickRestaurant(h.db, filter, c.Query("weight") == "rating")
+	if err != nil {
+		respondError(c, err, "무작위 추천")
+		return
+	}
+	respond(c, http.StatusOK, pick)
+}
+
+func (h *Handler) listMenu(c *gin.Context) {
+	items, err := store.ListMenu(h.db, paramID(c, "id"))
+	if err != nil {
+		respondError(c, err, "메뉴 조회")
+		return
+	}
+	respond(c, http.StatusOK, gin.H{"menu": items})
+}
+
+// parseRestaurantFilter: category, search, lat/lng/radius, min_rating, exclude, open_now/open_at, min_price/max_price 쿼리 파라미터
+func parseRestaurantFilter(c *gin.Context) (store.RestaurantFilter, error) {
+	f := store.RestaurantFilter{Category: c.Query("category"), Search: c.Query("search")}
+
+	var err error
+	if f.Geo, err = store.ParseGeoFilter(c.Query("lat"), c.Query("lng"), c.Query("radius")); err != nil {
+		return f, err
+	}
+
+	if v := c.Query("min_rating"); v != "" {
+		f.MinRating, err = strconv.ParseFloat(v, 64)
+		if err != nil || f.MinRating < 0 || f.MinRating > 5 {
+			return f, errInvalidMinRating
+		}
+	}
+
+	// exclude=1,2,3 또는 exclude=1&exclude=2
+	for _, v := range c.QueryArray("exclude") {
+		for _, part := range strings.Split(v, ",") {
+			if part = strings.TrimSpace(part); part == "" {
+				continue
+			}
+			id, err := strconv.ParseUint(part, 10, 64)
+			if err != nil {
+				return f, errInvalidExclude
+			}
+			f.Exclude = append(f.Exclude, uint(id))
+		}
+	}
+
+	if f.OpenAt, err = store.ParseOpenAt(c.Query("open_now"), c.Query("open_at")); err != nil {
+		return f, err
+	}
+	f.Price, err = store.ParsePriceRange(c.Query("min_price"), c.Query("max_price"))
+	return f, err
+}
+
+// encodeCursor: 다음 페이지 위치를 불투명한 커서 문자열로 변환
+func encodeCursor(offset int) string {
+	return base64.RawURLEncoding.EncodeToString([]byte("o:" + strconv.Itoa(offset)))
+}
+
+// decodeCursor: encodeCursor로 만든 커서를 offset으로 되돌림
+func decodeCursor(cursor string) (int, bool) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, false
+	}
+	n, err := strconv.Atoi(strings.TrimPrefix(string(raw), "o:"))
+	if err != nil || n < 0 || !strings.HasPrefix(string(raw), "o:") {
+		return 0, false
+	}
+	return n, true
+}
+
+// nextCursor: 다음 페이지가 있으면 커서, 없으면 nil
+func nextCursor(offset, limit int, total int64) *string {
+	if int64(offset+limit) >= total {
+		return nil
+	}
+	cursor := encodeCursor(offset + limit)
+	return &cursor
+}
@@ -0,0 +1,243 @@
+package handler
+
+import (
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"restaurant-api/internal/store"
+)
+
+// RoomRequest: 방 만들기 요청 (제목이 없으면 기본 제목)
+type RoomRequest struct {
+	Title string `json:"title"`
+}
+
+// CandidateRequest: 후보 식당 제안 요청
+type CandidateRequest struct {
+	RestaurantID uint `json:"restaurant_id" required:"true" minimum:"1"`
+}
+
+// VoteRequest: 투표 요청
+type VoteRequest struct {
+	CandidateID uint `json:"candidate_id" required:"true" minimum:"1"`
+}
+
+// roomHub: 방별 실시간 알림 구독자 (서버 한 대 기준, 상태 자체는 DB에 있음)
+type roomHub struct {
+	mu   sync.Mutex
+	subs map[string]map[chan struct{}]bool
+}
+
+func newRoomHub() *roomHub {
+	return &roomHub{subs: map[string]map[chan struct{}]bool{}}
+}
+
+// subscribe: 방 변경 알림 채널과 구독 해제 함수
+func (rh *roomHub) subscribe(code string) (chan struct{}, func()) {
+	ch := make(chan struct{}, 1)
+	rh.mu.Lock()
+	if rh.subs[code] == nil {
+		rh.subs[code] = map[chan struct{}]bool{}
+	}
+	rh.subs[code][ch] = true
+	rh.mu.Unlock()
+
+	return ch, func() {
+		rh.mu.Lock()
+		delete(rh.subs[code], ch)
+		if len(rh.subs[code]) == 0 {
+			delete(rh.subs, code)
+		}
+		rh.mu.Unlock()
+	}
+}
+
+// publish: 방 구독자에게 변경 알림 (이미 알림이 대기 중이면 합침)
+func (rh *roomHub) publish(code string) {
+	rh.mu.Lock()
+	defer rh.mu.Unlock()
+	for ch := range rh.subs[code] {
+		select {
+		case ch <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// roomRoutes: 같이 먹기 방 API (모두 로그인 필요)
+func (h *Handler) roomRoutes(me apiRoutes) {
+	// 방 만들기 (만든 사람이 방장)
+	me.POST("/rooms", h.createRoom)
+
+	// 코드로 방 참여
+	me.POST("/rooms/:code/join", h.joinRoom)
+
+	// 방 상태 (참여자, 후보별 득표 수, 결과)
+	me.GET("/rooms/:code", h.getRoom)
+
+	// 후보 식당 제안
+	me.POST("/rooms/:code/candidates", h.proposeCandidate)
+
+	// 투표 (다시 투표하면 바뀜)
+	me.PUT("/rooms/:code/vote", h.voteRoom)
+
+	// 투표 마감 및 결과 발표 (방장만)
+	me.POST("/rooms/:code/close", h.closeRoom)
+
+	// 실시간 알림 (Server-Sent Events). 변경될 때마다 state 이벤트, 마감되면 result 이벤트를 보낸다.
+	// 방 상태는 DB에 있으므로 서버가 재시작되어도 다시 연결하면 이어서 받을 수 있다.
+	// 이벤트 데이터는 /api/v1에서도 봉투 없이 그대로 보낸다.
+	me.GET("/rooms/:code/events", h.roomEvents)
+}
+
+func (h *Handler) createRoom(c *gin.Context) {
+	userID, _ := sessionUserID(c)
+	var req RoomRequest
+	// 본문이 없으면 기본 제목, 잘못된 JSON은 거부
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		fail(c, "bad_request")
+		return
+	}
+
+	room, err := store.CreateRoom(h.db, userID, req.Title)
+	if err != nil {
+		respondError(c, err, "방 만들기")
+		return
+	}
+	h.respondRoomState(c, http.StatusCreated, room, userID)
+}
+
+func (h *Handler) joinRoom(c *gin.Context) {
+	userID, _ := sessionUserID(c)
+	room, err := store.JoinRoom(h.db, c.Param("code"), userID)
+	if err != nil {
+		respondError(c, err, "방 참여")
+		return
+	}
+	h.rooms.publish(room.Code)
+	h.respondRoomState(c, http.StatusOK, room, userID)
+}
+
+func (h *Handler) getRoom(c *gin.Context) {
+	userID, _ := sessionUserID(c)
+	room, err := store.FindMemberRoom(h.db, c.Param("code"), userID)
+	if err != nil {
+		respondError(c, err, "방 조회")
+		return
+	}
+	h.respondRoomState(c, http.StatusOK, room, userID)
+}
+
+func (h *Handler) proposeCandidate(c *gin.Context) {
+	userID, _ := sessionUserID(c)
+	var req CandidateRequest
+	if err := c.ShouldBindJSON(&req); err != nil || req.RestaurantID == 0 {
+		failFields(c, "invalid_input", map[string]string{"restaurant_id": "restaurant_id.required"})
+		return
+	}
+
+	room, err := store.ProposeCandidate(h.db, c.Param("code"), userID, req.RestaurantID)
+	if err != nil {
+		respondError(c, err, "후보 제안")
+		return
+	}
+	h.rooms.publish(room.Code)
+	h.respondRoomState(c, http.StatusOK, room, userID)
+}
+
+func (h *Handler) voteRoom(c *gin.Context) {
+	userID, _ := sessionUserID(c)
+	var req VoteRequest
+	if err := c.ShouldBindJSON(&req); err != nil || req.CandidateID == 0 {
+		failFields(c, "invalid_input", map[string]string{"candidate_id": "candidate_id.required"})
+		return
+	}
+
+	room, err := store.VoteRoom(h.db, c.Param("code"), userID, req.CandidateID)
+	if err != nil {
+		respondError(c, err, "투표")
+		return
+	}
+	h.rooms.publish(room.Code)
+	h.respondRoomState(c, http.StatusOK, room, userID)
+}
+
+func (h *Handler) closeRoom(c *gin.Context) {
+	userID, _ := sessionUserID(c)
+	room, err := store.CloseRoom(h.db, c.Param("code"), userID)
+	if err != nil {
+		respondError(c, err, "투표 마감")
+		return
+	}
+	h.rooms.publish(room.Code)
+	h.respondRoomState(c, http.StatusOK, room, userID)
+}
+
+func (h *Handler) roomEvents(c *gin.Context) {
+	userID, _ := sessionUserID(c)
+	room, err := store.FindMemberRoom(h.db, c.Param("code"), userID)
+	if err != nil {
+		respondError(c, err, "방 조회")
+		return
+	}
+
+	updates, unsubscribe := h.rooms.subscribe(room.Code)
+	defer unsubscribe()
+	c.Header("Cache-Control", "no-cache")
+	c.Header("X-Accel-Buffering", "no") // 프록시 버퍼링 방지
+
+	send := func() bool {
+		if err := h.db.First(&room, room.ID).Error; err != nil {
+			log.Println("ERROR 방 상태 조회 실패:", err)
+			return false
+		}
+		state, err := store.LoadRoomState(h.db, room, userID)
+		if err != nil {
+			log.Println("ERROR 방 상태 조회 실패:", err)
+			return false
+		}
+		c.SSEvent("state", state)
+		if state.Winner != nil {
+			c.SSEvent("result", state.Winner)
+		}
+		c.Writer.Flush()
+		return true
+	}
+	if !send() {
+		return
+	}
+
+	keepAlive := time.NewTicker(25 * time.Second)
+	defer keepAlive.Stop()
+	for {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		case <-h.shutdown:
+			return // 서버 종료 중 (다시 연결하면 이어서 받을 수 있음)
+		case <-updates:
+			if !send() {
+				return
+			}
+		case <-keepAlive.C:
+			c.SSEvent("ping", time.Now().Unix())
+			c.Writer.Flush()
+		}
+	}
+}
+
+// respondRoomState: 방 상태 응답
+func (h *Handler) respondRoomState(c *gin.Context, status int, room store.Room, userID uint) {
+	state, err := store.LoadRoomState(h.db, room, userID)
+	if err != nil {
+		respondError(c, err, "방 상태 조회")
+		return
+	}
+	respond(c, status, state)
+}
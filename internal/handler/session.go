@@ -0,0 +1,193 @@
+package handler
+
+import (
+	"crypto/sha256"
+	"encoding/base32"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-contrib/sessions"
+	"github.com/gin-contrib/sessions/cookie"
+	"github.com/gorilla/securecookie"
+	gsessions "github.com/gorilla/sessions"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"restaurant-api/internal/config"
+	"restaurant-api/internal/store"
+)
+
+const sessionName = "mysession"
+
+// sessionKeyPairs: SESSION_KEYS에서 서명/암호화 키 쌍 생성.
+// 첫 번째 키로 새 쿠키를 만들고, 나머지 키는 이전 쿠키 검증에만 쓰여 키 교체가 가능하다.
+func sessionKeyPairs(secrets []string) [][]byte {
+	if len(secrets) == 0 {
+		log.Println("WARN  SESSION_KEYS가 없어 임시 키를 사용합니다. 재시작하면 모든 세션이 만료됩니다.")
+		secrets = []string{string(securecookie.GenerateRandomKey(32))}
+	}
+
+	pairs := make([][]byte, 0, len(secrets)*2)
+	for _, secret := range secrets {
+		hashKey := sha256.Sum256([]byte("hash:" + secret))
+		blockKey := sha256.Sum256([]byte("block:" + secret))
+		pairs = append(pairs, hashKey[:], blockKey[:])
+	}
+	return pairs
+}
+
+// sessionOptions: 세션 쿠키 옵션 (SESSION_MAX_AGE, SESSION_SECURE).
+// 직접 만든 Config처럼 MaxAge가 0 이하면 기본값을 쓴다 (0이면 저장소가 세션을 지우므로)
+func sessionOptions(cfg config.Config) sessions.Options {
+	maxAge := cfg.SessionMaxAge
+	if maxAge <= 0 {
+		maxAge = config.DefaultSessionMaxAge
+	}
+	return sessions.Options{
+		Path:     "/",
+		MaxAge:   maxAge,
+		HttpOnly: true,
+		Secure:   cfg.SessionSecure,
+		// 카카오에서 돌아오는 최상위 GET 요청에도 쿠키가 실려야 하므로 Strict가 아닌 Lax
+		SameSite: http.SameSiteLaxMode,
+	}
+}
+
+// newSessionStore: SESSION_STORE=cookie면 쿠키 저장소, 그 외에는 DB 저장소
+func newSessionStore(cfg config.Config, db *gorm.DB) sessions.Store {
+	var s sessions.Store
+	if cfg.SessionStore == "cookie" {
+		s = cookie.NewStore(sessionKeyPairs(cfg.SessionKeys)...)
+	} else {
+		s = newDBSessionStore(db, sessionKeyPairs(cfg.SessionKeys)...)
+	}
+	s.Options(sessionOptions(cfg))
+	return s
+}
+
+// dbSessionStore: 세션 값을 DB에 두고 쿠키에는 서명된 세션 ID만 담는 저장소.
+// 행을 지우면 해당 세션은 즉시 무효가 된다.
+type dbSessionStore struct {
+	db      *gorm.DB
+	codecs  []securecookie.Codec
+	options *gsessions.Options
+}
+
+func newDBSessionStore(db *gorm.DB, keyPairs ...[]byte) *dbSessionStore {
+	return &dbSessionStore{db: db, codecs: securecookie.CodecsFromPairs(keyPairs...)}
+}
+
+func (s *dbSessionStore) Options(options sessions.Options) {
+	s.options = options.ToGorillaOptions()
+	for _, codec := range s.codecs {
+		if sc, ok := codec.(*securecookie.SecureCookie); ok {
+			sc.MaxAge(options.MaxAge)
+		}
+	}
+}
+
+func (s *dbSessionStore) Get(r *http.Request, name string) (*gsessions.Session, error) {
+	return gsessions.GetRegistry(r).Get(s, name)
+}
+
+// New: 쿠키의 세션 ID로 DB에서 세션을 읽음. 없거나 만료됐으면 새 세션
+func (s *dbSessionStore) New(r *http.Request, name string) (*gsessions.Session, error) {
+	session := gsessions.NewSession(s, name)
+	opts := *s.options
+	session.Options = &opts
+	session.IsNew = true
+
+	c, err := r.Cookie(name)
+	if err != nil {
+		return session, nil
+	}
+	if err := securecookie.DecodeMulti(name, c.Value, &session.ID, s.codecs...); err != nil {
+		session.ID = ""
+		return session, nil
+	}
+
+	var record store.SessionRecord
+	result := s.db.Where("id = ? AND expires_at > ?", session.ID, time.Now()).Limit(1).Find(&record)
+	if result.Error != nil {
+		return session, result.Error
+	}
+	if result.RowsAffected == 0 {
+		// 로그아웃/강제 만료된 세션
+		session.ID = ""
+		return session, nil
+	}
+	if err := securecookie.DecodeMulti(name, record.Data, &session.Values, s.codecs...); err != nil {
+		session.ID = ""
+		return session, nil
+	}
+	session.IsNew = false
+	return session, nil
+}
+
+// Save: MaxAge <= 0이면 DB에서 세션을 지우고 쿠키도 만료시킴
+func (s *dbSessionStore) Save(r *http.Request, w http.ResponseWriter, session *gsessions.Session) error {
+	if session.Options.MaxAge <= 0 {
+		if session.ID != "" {
+			if err := s.db.Delete(&store.SessionRecord{}, "id = ?", session.ID).Error; err != nil {
+				return err
+			}
+		}
+		http.SetCookie(w, gsessions.NewCookie(session.Name(), "", session.Options))
+		return nil
+	}
+
+	userID, hasUser := session.Values["userID"].(uint)
+	if session.ID != "" && hasUser {
+		if err := s.rotateOnLogin(session, userID); err != nil {
+			return err
+		}
+	}
+	if session.ID == "" {
+		session.ID = strings.TrimRight(base32.StdEncoding.EncodeToString(securecookie.GenerateRandomKey(32)), "=")
+	}
+	data, err := securecookie.EncodeMulti(session.Name(), session.Values, s.codecs...)
+	if err != nil {
+		return err
+	}
+
+	record := store.SessionRecord{
+		ID:        session.ID,
+		Data:      data,
+		ExpiresAt: time.Now().Add(time.Duration(session.Options.MaxAge) * time.Second),
+	}
+	if hasUser {
+		record.UserID = &userID
+	}
+	if err := s.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"user_id", "data", "expires_at", "updated_at"}),
+	}).Create(&record).Error; err != nil {
+		return err
+	}
+
+	encoded, err := securecookie.EncodeMulti(session.Name(), session.ID, s.codecs...)
+	if err != nil {
+		return err
+	}
+	http.SetCookie(w, gsessions.NewCookie(session.Name(), encoded, session.Options))
+	return nil
+}
+
+// rotateOnLogin: 저장된 세션의 사용자와 다르면(로그인 직후) 기존 행을 지우고 새 세션 ID를 쓰게 함.
+// 로그인 전에 심어 둔 세션 ID를 그대로 로그인 세션으로 쓰는 세션 고정 공격을 막는다.
+func (s *dbSessionStore) rotateOnLogin(session *gsessions.Session, userID uint) error {
+	var record store.SessionRecord
+	if err := s.db.Select("user_id").Where("id = ?", session.ID).Limit(1).Find(&record).Error; err != nil {
+		return err
+	}
+	if record.UserID != nil && *record.UserID == userID {
+		return nil
+	}
+	if err := s.db.Delete(&store.SessionRecord{}, "id = ?", session.ID).Error; err != nil {
+		return err
+	}
+	session.ID = ""
+	return nil
+}
@@ -0,0 +1,228 @@
+package kakao
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// FakeUser: 가짜 카카오 서버로 로그인할 수 있는 테스트 사용자
+type FakeUser struct {
+	ID       int64
+	Nickname string
+}
+
+// DefaultFakeUsers: KAKAO_FAKE_USERS가 없을 때의 테스트 사용자
+var DefaultFakeUsers = []FakeUser{
+	{ID: 1001, Nickname: "테스트 사용자1"},
+	{ID: 1002, Nickname: "테스트 사용자2"},
+	{ID: 1003, Nickname: "테스트 관리자"},
+}
+
+// ParseFakeUsers: "카카오ID:닉네임" 목록을 테스트 사용자로 (비어 있으면 DefaultFakeUsers)
+func ParseFakeUsers(list []string) ([]FakeUser, error) {
+	if len(list) == 0 {
+		return DefaultFakeUsers, nil
+	}
+	users := make([]FakeUser, 0, len(list))
+	for _, entry := range list {
+		idStr, nickname, ok := strings.Cut(entry, ":")
+		id, err := strconv.ParseInt(strings.TrimSpace(idStr), 10, 64)
+		if !ok || err != nil || id <= 0 || strings.TrimSpace(nickname) == "" {
+			return nil, fmt.Errorf("kakao: invalid fake user %q (카카오ID:닉네임 형식)", entry)
+		}
+		users = append(users, FakeUser{ID: id, Nickname: strings.TrimSpace(nickname)})
+	}
+	return users, nil
+}
+
+// FakeServer: 개발/테스트용 가짜 카카오 인증·API 서버. Client가 쓰는 경로만 구현한다.
+//
+//	GET  /oauth/authorize  테스트 사용자 선택 화면 (user=카카오ID가 있으면 바로 redirect_uri로 인가 코드 전달)
+//	POST /oauth/token      인가 코드를 액세스 토큰으로 교환 (코드는 한 번만 사용)
+//	GET  /v2/user/me       액세스 토큰의 사용자 정보
+//
+// Config.AuthURL과 Config.APIURL을 이 서버 주소로 지정해서 쓴다.
+type FakeServer struct {
+	users []FakeUser
+	mux   *http.ServeMux
+
+	mu     sync.Mutex
+	codes  map[string]fakeGrant // 인가 코드 → 발급 정보
+	tokens map[string]int64     // 액세스 토큰 → 카카오 ID
+}
+
+// fakeGrant: 인가 코드를 발급할 때의 요청 값 (토큰 교환 시 같은지 확인)
+type fakeGrant struct {
+	userID      int64
+	clientID    string
+	redirectURI string
+}
+
+// NewFakeServer: 테스트 사용자로 가짜 서버 생성 (users가 비어 있으면 DefaultFakeUsers)
+func NewFakeServer(users []FakeUser) *FakeServer {
+	if len(users) == 0 {
+		users = DefaultFakeUsers
+	}
+	s := &FakeServer{
+		users:  users,
+		mux:    http.NewServeMux(),
+		codes:  map[string]fakeGrant{},
+		tokens: map[string]int64{},
+	}
+	s.mux.HandleFunc("GET /oauth/authorize", s.authorize)
+	s.mux.HandleFunc("POST /oauth/token", s.token)
+	s.mux.HandleFunc("/v2/user/me", s.userMe)
+	return s
+}
+
+func (s *FakeServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// Users: 로그인할 수 있는 테스트 사용자
+func (s *FakeServer) Users() []FakeUser {
+	return s.users
+}
+
+func (s *FakeServer) findUser(id int64) (FakeUser, bool) {
+	for _, u := range s.users {
+		if u.ID == id {
+			return u, true
+		}
+	}
+	return FakeUser{}, false
+}
+
+var fakeAuthorizePage = template.Must(template.New("authorize").Parse(`<!DOCTYPE html>
+<html lang="ko">
+<head><meta charset="UTF-8"><title>가짜 카카오 로그인</title></head>
+<body style="font-family: sans-serif; max-width: 420px; margin: 40px auto;">
+<h1 style="font-size: 20px;">가짜 카카오 로그인</h1>
+<p style="color: #707070;">개발/테스트용 서버입니다. 로그인할 테스트 사용자를 고르세요.</p>
+<ul>{{range .Users}}
+<li><a href="{{$.Base}}&user={{.ID}}">{{.Nickname}}</a> ({{.ID}})</li>{{end}}
+</ul>
+</body>
+</html>
+`))
+
+// authorize: user가 없으면 사용자 선택 화면, 있으면 인가 코드를 붙여 redirect_uri로 이동
+func (s *FakeServer) authorize(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	redirectURI := q.Get("redirect_uri")
+	if q.Get("response_type") != "code" || redirectURI == "" {
+		http.Error(w, "response_type=code와 redirect_uri가 필요합니다.", http.StatusBadRequest)
+		return
+	}
+
+	userParam := q.Get("user")
+	if userParam == "" {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		fakeAuthorizePage.Execute(w, map[string]any{
+			"Base":  "?" + q.Encode(), // 경로 접두사 아래에 붙여도 같은 주소로
+			"Users": s.users,
+		})
+		return
+	}
+
+	userID, _ := strconv.ParseInt(userParam, 10, 64)
+	if _, ok := s.findUser(userID); !ok {
+		http.Error(w, "없는 테스트 사용자입니다: "+userParam, http.StatusBadRequest)
+		return
+	}
+
+	code := newFakeSecret()
+	s.mu.Lock()
+	s.codes[code] = fakeGrant{userID: userID, clientID: q.Get("client_id"), redirectURI: redirectURI}
+	s.mu.Unlock()
+
+	params := url.Values{}
+	params.Set("code", code)
+	if state := q.Get("state"); state != "" {
+		params.Set("state", state)
+	}
+	sep := "?"
+	if strings.Contains(redirectURI, "?") {
+		sep = "&"
+	}
+	http.Redirect(w, r, redirectURI+sep+params.Encode(), http.StatusFound)
+}
+
+// token: authorization_code 방식의 토큰 발급 (오류는 kauth와 같은 error/error_code 형식)
+func (s *FakeServer) token(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeFakeJSON(w, http.StatusBadRequest, Error{ErrorType: "invalid_request", ErrorDescription: "invalid form body", ErrorCode: "KOE101"})
+		return
+	}
+	if r.PostForm.Get("grant_type") != "authorization_code" {
+		writeFakeJSON(w, http.StatusBadRequest, Error{ErrorType: "unsupported_grant_type", ErrorDescription: "grant_type must be authorization_code", ErrorCode: "KOE010"})
+		return
+	}
+
+	code := r.PostForm.Get("code")
+	s.mu.Lock()
+	grant, ok := s.codes[code]
+	delete(s.codes, code)
+	s.mu.Unlock()
+	if !ok {
+		writeFakeJSON(w, http.StatusBadRequest, Error{ErrorType: "invalid_grant", ErrorDescription: "authorization code not found for code=" + code, ErrorCode: "KOE320"})
+		return
+	}
+	if r.PostForm.Get("redirect_uri") != grant.redirectURI || r.PostForm.Get("client_id") != grant.clientID {
+		writeFakeJSON(w, http.StatusBadRequest, Error{ErrorType: "invalid_grant", ErrorDescription: "Redirect URI mismatch.", ErrorCode: "KOE303"})
+		return
+	}
+
+	token := newFakeSecret()
+	s.mu.Lock()
+	s.tokens[token] = grant.userID
+	s.mu.Unlock()
+
+	writeFakeJSON(w, http.StatusOK, TokenResponse{
+		AccessToken:  token,
+		TokenType:    "bearer",
+		RefreshToken: newFakeSecret(),
+		ExpiresIn:    21599,
+		Scope:        "profile_nickname",
+	})
+}
+
+// userMe: Bearer 토큰의 사용자 정보 (오류는 kapi와 같은 code/msg 형식)
+func (s *FakeServer) userMe(w http.ResponseWriter, r *http.Request) {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	s.mu.Lock()
+	userID, found := s.tokens[token]
+	s.mu.Unlock()
+	user, exists := s.findUser(userID)
+	if !ok || !found || !exists {
+		writeFakeJSON(w, http.StatusUnauthorized, Error{Code: -401, Msg: "this access token does not exist"})
+		return
+	}
+
+	var res UserResponse
+	res.ID = user.ID
+	res.Properties.Nickname = user.Nickname
+	res.KakaoAccount.Profile.Nickname = user.Nickname
+	writeFakeJSON(w, http.StatusOK, res)
+}
+
+func writeFakeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json;charset=UTF-8")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+// newFakeSecret: 인가 코드/토큰용 임의 문자열
+func newFakeSecret() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
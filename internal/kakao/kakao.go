@@ -0,0 +1,198 @@
+// Package kakao: 카카오 로그인(OAuth)과 사용자 정보 API 클라이언트
+package kakao
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// 카카오 API 기본 주소 (Config.AuthURL, Config.APIURL로 변경 가능)
+const (
+	defaultAuthURL = "https://kauth.kakao.com"
+	defaultAPIURL  = "https://kapi.kakao.com"
+)
+
+// 카카오 응답 본문 최대 크기
+const maxBody = 1 << 20
+
+// defaultHTTPClient: 카카오 호출용 HTTP 클라이언트 (응답이 없을 때 무한 대기 방지)
+var defaultHTTPClient = &http.Client{
+	Timeout: 10 * time.Second,
+	Transport: &http.Transport{
+		Proxy:                 http.ProxyFromEnvironment,
+		DialContext:           (&net.Dialer{Timeout: 5 * time.Second}).DialContext,
+		TLSHandshakeTimeout:   5 * time.Second,
+		ResponseHeaderTimeout: 5 * time.Second,
+		IdleConnTimeout:       90 * time.Second,
+	},
+}
+
+// TokenResponse: 카카오 토큰 발급 응답 구조체
+type TokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	TokenType    string `json:"token_type"`
+	RefreshToken string `json:"refresh_token"`
+	ExpiresIn    int    `json:"expires_in"`
+	Scope        string `json:"scope"`
+}
+
+// UserResponse: 카카오 사용자 정보 응답 구조체
+type UserResponse struct {
+	ID         int64 `json:"id"`
+	Properties struct {
+		Nickname string `json:"nickname"`
+	} `json:"properties"`
+	KakaoAccount struct {
+		Profile struct {
+			Nickname string `json:"nickname"`
+		} `json:"profile"`
+	} `json:"kakao_account"`
+}
+
+// Error: 카카오 오류 응답.
+// 인증 서버(kauth)는 error/error_description/error_code, API 서버(kapi)는 code/msg 형식으로 내려준다.
+type Error struct {
+	StatusCode       int    `json:"-"`
+	ErrorType        string `json:"error,omitempty"`             // 예: invalid_grant
+	ErrorDescription string `json:"error_description,omitempty"` // 오류 설명
+	ErrorCode        string `json:"error_code,omitempty"`        // 예: KOE320
+	Code             int    `json:"code,omitempty"`              // 예: -401
+	Msg              string `json:"msg,omitempty"`
+}
+
+func (e *Error) Error() string {
+	switch {
+	case e.ErrorType != "":
+		return fmt.Sprintf("kakao: %d %s (%s): %s", e.StatusCode, e.ErrorType, e.ErrorCode, e.ErrorDescription)
+	case e.Msg != "":
+		return fmt.Sprintf("kakao: %d code=%d: %s", e.StatusCode, e.Code, e.Msg)
+	default:
+		return fmt.Sprintf("kakao: unexpected status %d", e.StatusCode)
+	}
+}
+
+// 카카오가 정상 상태 코드로 쓸모없는 응답을 준 경우
+var (
+	ErrEmptyToken = errors.New("kakao: empty access token")
+	ErrEmptyUser  = errors.New("kakao: empty user id")
+)
+
+// Nickname: 프로필 닉네임 (properties에 없으면 kakao_account.profile)
+func (u *UserResponse) Nickname() string {
+	if u.Properties.Nickname != "" {
+		return u.Properties.Nickname
+	}
+	return u.KakaoAccount.Profile.Nickname
+}
+
+// Config: 앱 키와 카카오 서버 주소 (주소가 비어 있으면 실제 카카오 서버)
+type Config struct {
+	ClientID     string // REST API 키
+	ClientSecret string
+	AuthURL      string // 인증 서버 (kauth)
+	APIURL       string // API 서버 (kapi)
+}
+
+// Client: 카카오 로그인 클라이언트
+type Client struct {
+	cfg  Config
+	http *http.Client
+}
+
+// New: 카카오 클라이언트 생성
+func New(cfg Config) *Client {
+	cfg.AuthURL = strings.TrimRight(cfg.AuthURL, "/")
+	if cfg.AuthURL == "" {
+		cfg.AuthURL = defaultAuthURL
+	}
+	cfg.APIURL = strings.TrimRight(cfg.APIURL, "/")
+	if cfg.APIURL == "" {
+		cfg.APIURL = defaultAPIURL
+	}
+	return &Client{cfg: cfg, http: defaultHTTPClient}
+}
+
+// AuthorizeURL: 카카오 로그인 화면 주소
+func (c *Client) AuthorizeURL(redirectURI, state string) string {
+	params := url.Values{}
+	params.Set("client_id", c.cfg.ClientID)
+	params.Set("redirect_uri", redirectURI)
+	params.Set("response_type", "code")
+	params.Set("state", state)
+	return c.cfg.AuthURL + "/oauth/authorize?" + params.Encode()
+}
+
+// Token: 인가 코드로 액세스 토큰 발급
+func (c *Client) Token(code, redirectURI string) (*TokenResponse, error) {
+	params := url.Values{}
+	params.Add("grant_type", "authorization_code")
+	params.Add("client_id", c.cfg.ClientID)
+	params.Add("redirect_uri", redirectURI)
+	params.Add("code", code)
+	if c.cfg.ClientSecret != "" {
+		params.Add("client_secret", c.cfg.ClientSecret)
+	}
+
+	resp, err := c.http.PostForm(c.cfg.AuthURL+"/oauth/token", params)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var tokenRes TokenResponse
+	if err := decodeResponse(resp, &tokenRes); err != nil {
+		return nil, err
+	}
+	if tokenRes.AccessToken == "" {
+		return nil, ErrEmptyToken
+	}
+	return &tokenRes, nil
+}
+
+// UserInfo: 액세스 토큰으로 사용자 정보 조회
+func (c *Client) UserInfo(token string) (*UserResponse, error) {
+	req, err := http.NewRequest("GET", c.cfg.APIURL+"/v2/user/me", nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Add("Authorization", "Bearer "+token)
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var userRes UserResponse
+	if err := decodeResponse(resp, &userRes); err != nil {
+		return nil, err
+	}
+	if userRes.ID == 0 {
+		return nil, ErrEmptyUser
+	}
+	return &userRes, nil
+}
+
+// decodeResponse: 2xx면 본문을 v로 디코딩하고, 아니면 *Error를 반환
+func decodeResponse(resp *http.Response, v any) error {
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxBody))
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		kakaoErr := &Error{StatusCode: resp.StatusCode}
+		json.Unmarshal(body, kakaoErr) // 형식이 달라도 상태 코드는 남긴다
+		return kakaoErr
+	}
+	if err := json.Unmarshal(body, v); err != nil {
+		return fmt.Errorf("kakao: decode response: %w", err)
+	}
+	return nil
+}
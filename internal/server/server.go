@@ -0,0 +1,146 @@
+// Package server: 로그, DB, 카카오 클라이언트를 준비하고 Gin 엔진을 띄운다
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"restaurant-api/internal/config"
+	"restaurant-api/internal/handler"
+	"restaurant-api/internal/kakao"
+	"restaurant-api/internal/store"
+)
+
+// 내장 가짜 카카오 서버 경로 (KAKAO_FAKE=true일 때만)
+const fakeKakaoPath = "/_fake/kakao"
+
+// New: 미들웨어, 정적 파일, 화면 템플릿과 라우트를 등록한 Gin 엔진 (ctx가 끝나면 실시간 알림 연결을 닫음)
+func New(ctx context.Context, cfg config.Config, db *gorm.DB, kc handler.KakaoClient) *gin.Engine {
+	r := gin.New()
+	r.Use(gin.Recovery())
+
+	// 커스텀 로그 포맷터 설정
+	r.Use(gin.LoggerWithFormatter(func(param gin.LogFormatterParams) string {
+		level := "INFO "
+		if param.StatusCode >= 400 && param.StatusCode < 500 {
+			level = "WARN "
+		} else if param.StatusCode >= 500 {
+			level = "ERROR"
+		}
+
+		return fmt.Sprintf("%s [%d] %s %s (%s)\n",
+			level,
+			param.StatusCode,
+			param.Method,
+			param.Path,
+			param.Latency,
+		)
+	}))
+
+	// 💡 여기서 정적 파일(CSS, JS) 경로를 설정해 줍니다.
+	// /static 경로로 들어오는 요청은 ASSET_DIR(기본 현재 폴더)의 static 폴더 안에서 찾아서 응답합니다.
+	r.Static("/static", filepath.Join(cfg.AssetDir, "static"))
+
+	r.LoadHTMLGlob(filepath.Join(cfg.AssetDir, "index.html"))
+
+	handler.New(ctx, cfg, db, kc).Register(r)
+	return r
+}
+
+// Run: 로그 파일과 DB를 준비하고 서버 실행. SIGINT/SIGTERM을 받으면 정리 작업을 멈추고 서버를 종료한다
+func Run(cfg config.Config) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// 1. 로그 시스템 설정
+	if _, err := os.Stat("logs"); os.IsNotExist(err) {
+		os.Mkdir("logs", 0755)
+	}
+
+	f, err := os.OpenFile("logs/app.log", os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("로그 파일을 열 수 없습니다: %w", err)
+	}
+
+	multiWriter := io.MultiWriter(f, os.Stdout)
+	gin.DefaultWriter = multiWriter
+	log.SetOutput(multiWriter)
+	log.SetFlags(0)
+
+	// 2. DB 초기화
+	db, err := store.Open(cfg.DBPath)
+	if err != nil {
+		return fmt.Errorf("DB 연결 실패: %w", err)
+	}
+	// 내장 시드 데이터 반영 (버전이 올라간 경우에만)
+	store.SeedOnStartup(db)
+	log.Println("INFO  app started")
+
+	go store.CleanupExpiredSessions(ctx, db, time.Hour)
+
+	// 3. 카카오 로그인 클라이언트 (KAKAO_AUTH_URL/KAKAO_API_URL로 서버 주소 변경 가능)
+	kakaoCfg := kakao.Config{
+		ClientID:     cfg.KakaoRESTKey,
+		ClientSecret: cfg.KakaoClientSecret,
+		AuthURL:      cfg.KakaoAuthURL,
+		APIURL:       cfg.KakaoAPIURL,
+	}
+	var fake *kakao.FakeServer
+	if cfg.KakaoFake {
+		users, err := kakao.ParseFakeUsers(cfg.KakaoFakeUsers)
+		if err != nil {
+			return fmt.Errorf("KAKAO_FAKE_USERS 설정 오류: %w", err)
+		}
+		fake = kakao.NewFakeServer(users)
+		// 브라우저와 이 서버 모두 같은 주소로 가짜 서버에 접근
+		kakaoCfg.AuthURL = fakeKakaoURL(cfg)
+		kakaoCfg.APIURL = kakaoCfg.AuthURL
+		log.Println("WARN  가짜 카카오 로그인(KAKAO_FAKE)을 사용합니다. 운영 환경에서는 끄세요:", kakaoCfg.AuthURL)
+	}
+
+	r := New(ctx, cfg, db, kakao.New(kakaoCfg))
+	if fake != nil {
+		mountFakeKakao(r, fake)
+	}
+	srv := &http.Server{Addr: cfg.Addr(), Handler: r}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		srv.Shutdown(shutdownCtx)
+	}()
+
+	log.Printf("INFO  server listening on %s\n", cfg.Addr())
+	if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	log.Println("INFO  server stopped")
+	return nil
+}
+
+// mountFakeKakao: 가짜 카카오 서버를 fakeKakaoPath 아래에 붙임
+func mountFakeKakao(r *gin.Engine, fake *kakao.FakeServer) {
+	r.Any(fakeKakaoPath+"/*path", gin.WrapH(http.StripPrefix(fakeKakaoPath, fake)))
+}
+
+// fakeKakaoURL: 내장 가짜 카카오 서버 주소 (APP_DOMAIN이 없으면 localhost)
+func fakeKakaoURL(cfg config.Config) string {
+	base := cfg.AppDomain
+	if base == "" {
+		base = "http://localhost:" + cfg.Port
+	}
+	return strings.TrimRight(base, "/") + fakeKakaoPath
+}
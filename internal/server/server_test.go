@@ -0,0 +1,144 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/cookiejar"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"restaurant-api/internal/config"
+	"restaurant-api/internal/kakao"
+	"restaurant-api/internal/store"
+)
+
+// TestFakeKakaoLoginFlow: 내장 가짜 카카오 서버로 로그인 → 별점 평가 → 로그아웃 후 평가가 막히는지
+func TestFakeKakaoLoginFlow(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	db, err := store.Open(":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if sqlDB, err := db.DB(); err == nil {
+			sqlDB.Close()
+		}
+	})
+	res, err := store.CreateRestaurant(db, store.RestaurantInput{
+		Title: "가야밀면", Addr: "경기 안양시 만안구 성결대학로 1", Food: "한식", X: 126.93, Y: 37.38, URL: "https://place.map.kakao.com/1",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 리다이렉트 주소(APP_DOMAIN)가 서버 주소여야 하므로 서버를 먼저 띄우고 엔진을 붙인다
+	var app http.Handler
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		app.ServeHTTP(w, r)
+	}))
+	defer srv.Close()
+
+	cfg := config.Config{
+		AppDomain:    srv.URL,
+		AssetDir:     "../../cmd",
+		KakaoRESTKey: "test-rest-key",
+		SessionKeys:  []string{"test-session-key"},
+	}
+	kakaoURL := fakeKakaoURL(cfg)
+	r := New(context.Background(), cfg, db, kakao.New(kakao.Config{ClientID: cfg.KakaoRESTKey, AuthURL: kakaoURL, APIURL: kakaoURL}))
+	mountFakeKakao(r, kakao.NewFakeServer(nil))
+	app = r
+
+	jar, _ := cookiejar.New(nil)
+	client := &http.Client{
+		Jar: jar,
+		// 단계마다 확인하도록 리다이렉트는 직접 따라감
+		CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse },
+	}
+	get := func(rawURL string, wantStatus int) *http.Response {
+		t.Helper()
+		resp, err := client.Get(rawURL)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != wantStatus {
+			body, _ := io.ReadAll(resp.Body)
+			t.Fatalf("GET %s = %d, want %d: %s", rawURL, resp.StatusCode, wantStatus, body)
+		}
+		return resp
+	}
+	rate := func() (int, string) {
+		t.Helper()
+		resp, err := client.Post(srv.URL+"/api/v1/rate", "application/json", strings.NewReader(`{"restaurant_id": 1, "score": 4}`))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer resp.Body.Close()
+		var env struct {
+			Error *struct {
+				Code string `json:"code"`
+			} `json:"error"`
+		}
+		json.NewDecoder(resp.Body).Decode(&env)
+		if env.Error != nil {
+			return resp.StatusCode, env.Error.Code
+		}
+		return resp.StatusCode, ""
+	}
+
+	// 1. 로그인 시작 → 가짜 카카오 사용자 선택 화면
+	authorize := get(srv.URL+"/login/kakao?next=/", http.StatusFound).Header.Get("Location")
+	if !strings.HasPrefix(authorize, kakaoURL+"/oauth/authorize?") {
+		t.Fatalf("authorize URL = %q", authorize)
+	}
+	resp, err := client.Get(authorize)
+	if err != nil {
+		t.Fatal(err)
+	}
+	page, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || !strings.Contains(string(page), kakao.DefaultFakeUsers[0].Nickname) {
+		t.Fatalf("picker = %d %s", resp.StatusCode, page)
+	}
+
+	// 2. 사용자 선택 → 인가 코드와 함께 콜백 → 메인 화면
+	callback := get(authorize+"&user=1001", http.StatusFound).Header.Get("Location")
+	if !strings.HasPrefix(callback, srv.URL+"/auth/kakao/callback?") {
+		t.Fatalf("callback URL = %q", callback)
+	}
+	if next := get(callback, http.StatusFound).Header.Get("Location"); next != "/" {
+		t.Fatalf("after login = %q", next)
+	}
+	var user store.User
+	if err := db.Where("kakao_id = ?", 1001).First(&user).Error; err != nil || user.Nickname != kakao.DefaultFakeUsers[0].Nickname {
+		t.Fatalf("user = %+v, %v", user, err)
+	}
+
+	// 같은 콜백을 다시 보내면 거부 (state는 한 번만 사용)
+	get(callback, http.StatusBadRequest)
+
+	// 3. 별점 평가
+	if status, code := rate(); status != http.StatusOK {
+		t.Fatalf("rate = %d %s", status, code)
+	}
+	if err := db.First(&res, res.ID).Error; err != nil || res.RatingCount != 1 || res.AvgRating != 4 {
+		t.Fatalf("restaurant = %+v, %v", res, err)
+	}
+
+	// 4. 로그아웃 후에는 평가할 수 없음
+	get(srv.URL+"/logout", http.StatusFound)
+	if status, code := rate(); status != http.StatusUnauthorized || code != "login_required" {
+		t.Fatalf("rate after logout = %d %q", status, code)
+	}
+	if u, _ := url.Parse(srv.URL); len(jar.Cookies(u)) != 0 {
+		t.Fatalf("cookies after logout = %v", jar.Cookies(u))
+	}
+}
@@ -0,0 +1,151 @@
+package store
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+	"unicode/utf8"
+
+	"gorm.io/gorm"
+)
+
+const RoleAdmin = "admin" // 관리자 역할 (User.Role)
+
+// 지도에 표시할 수 있는 좌표 범위 (대한민국 인근)
+const (
+	minLat, maxLat = 33.0, 39.0
+	minLng, maxLng = 124.0, 132.0
+)
+
+var ErrRestaurantNotDeleted = errors.New("restaurant not deleted")
+
+// RestaurantInput: 관리자 식당 등록/수정 요청
+type RestaurantInput struct {
+	Title string  `json:"title" required:"true" maxLength:"100"`
+	Addr  string  `json:"addr" required:"true" maxLength:"200"`
+	Food  string  `json:"food"`
+	X     float64 `json:"x" required:"true"` // 경도
+	Y     float64 `json:"y" required:"true"` // 위도
+	URL   string  `json:"url" required:"true"`
+}
+
+// Validate: 필드별 오류 코드 (문제가 없으면 빈 맵, 메시지는 handler가 언어에 맞게 만든다)
+func (in *RestaurantInput) Validate() map[string]string {
+	in.Title = strings.TrimSpace(in.Title)
+	in.Addr = strings.TrimSpace(in.Addr)
+	in.Food = strings.TrimSpace(in.Food)
+	in.URL = strings.TrimSpace(in.URL)
+
+	errs := map[string]string{}
+	if in.Title == "" || utf8.RuneCountInString(in.Title) > 100 {
+		errs["title"] = "title.length"
+	}
+	if in.Addr == "" || utf8.RuneCountInString(in.Addr) > 200 {
+		errs["addr"] = "addr.length"
+	}
+	if in.Y < minLat || in.Y > maxLat {
+		errs["y"] = "y.range"
+	}
+	if in.X < minLng || in.X > maxLng {
+		errs["x"] = "x.range"
+	}
+	if u, err := url.Parse(in.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		errs["url"] = "url.invalid"
+	}
+	return errs
+}
+
+// apply: 입력 값을 식당 레코드에 반영
+func (in RestaurantInput) apply(res *Restaurant) {
+	res.Title, res.Addr, res.Food = in.Title, in.Addr, in.Food
+	res.X, res.Y, res.URL = in.X, in.Y, in.URL
+	res.PlaceID = placeIDPtr(placeIDFromURL(in.URL))
+}
+
+// CreateRestaurant: 식당 등록 및 Food 기준 카테고리 연결
+func CreateRestaurant(db *gorm.DB, in RestaurantInput) (Restaurant, error) {
+	var res Restaurant
+	in.apply(&res)
+	err := indexTransaction(db, func(tx *gorm.DB) error {
+		if err := checkPlaceID(tx, res.PlaceID, 0); err != nil {
+			return err
+		}
+		if err := tx.Create(&res).Error; err != nil {
+			return err
+		}
+		if err := setRestaurantCategories(tx, res.ID, res.Food); err != nil {
+			return err
+		}
+		return reindexRestaurant(tx, res.ID)
+	})
+	if err != nil {
+		return res, err
+	}
+	return res, preloadDetails(db).First(&res, res.ID).Error
+}
+
+// UpdateRestaurant: 식당 정보 수정 (별점 집계는 그대로 유지)
+func UpdateRestaurant(db *gorm.DB, id uint, in RestaurantInput) (Restaurant, error) {
+	var res Restaurant
+	err := indexTransaction(db, func(tx *gorm.DB) error {
+		if err := tx.First(&res, id).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrRestaurantNotFound
+			}
+			return err
+		}
+		in.apply(&res)
+		if err := checkPlaceID(tx, res.PlaceID, res.ID); err != nil {
+			return err
+		}
+		if err := tx.Select("Title", "Addr", "Food", "X", "Y", "URL", "PlaceID").Updates(&res).Error; err != nil {
+			return err
+		}
+		if err := setRestaurantCategories(tx, res.ID, res.Food); err != nil {
+			return err
+		}
+		return reindexRestaurant(tx, res.ID)
+	})
+	if err != nil {
+		return res, err
+	}
+	return res, preloadDetails(db).First(&res, res.ID).Error
+}
+
+// DeleteRestaurant: 식당 소프트 삭제 (별점/리뷰는 보존)
+func DeleteRestaurant(db *gorm.DB, id uint) error {
+	return indexTransaction(db, func(tx *gorm.DB) error {
+		result := tx.Delete(&Restaurant{}, id)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrRestaurantNotFound
+		}
+		return reindexRestaurant(tx, id)
+	})
+}
+
+// RestoreRestaurant: 소프트 삭제된 식당 복구
+func RestoreRestaurant(db *gorm.DB, id uint) (Restaurant, error) {
+	var res Restaurant
+	if err := db.Unscoped().First(&res, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return res, ErrRestaurantNotFound
+		}
+		return res, err
+	}
+	if !res.DeletedAt.Valid {
+		return res, ErrRestaurantNotDeleted
+	}
+	err := indexTransaction(db, func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Model(&res).Update("deleted_at", nil).Error; err != nil {
+			return err
+		}
+		return reindexRestaurant(tx, id)
+	})
+	if err != nil {
+		return res, err
+	}
+	return res, preloadDetails(db).First(&res, id).Error
+}
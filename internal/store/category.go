@@ -0,0 +1,136 @@
+package store
+
+import (
+	"log"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// CategoryCount: 카테고리별 식당 수 응답 항목
+type CategoryCount struct {
+	ID    uint   `json:"id"`
+	Name  string `json:"name"`
+	Count int64  `json:"count"`
+}
+
+// splitFood: "호프, 요리주점" 같은 Food 문자열을 태그 목록으로 분리
+func splitFood(food string) []string {
+	names := []string{}
+	seen := map[string]bool{}
+	for _, name := range strings.Split(food, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+		names = append(names, name)
+	}
+	return names
+}
+
+// findOrCreateCategories: 이름 목록에 해당하는 카테고리를 (없으면 만들어) 반환
+func findOrCreateCategories(tx *gorm.DB, names []string) ([]Category, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+	var categories []Category
+	if err := tx.Where("name IN ?", names).Find(&categories).Error; err != nil {
+		return nil, err
+	}
+	existing := map[string]bool{}
+	for _, cat := range categories {
+		existing[cat.Name] = true
+	}
+	for _, name := range names {
+		if existing[name] {
+			continue
+		}
+		cat := Category{Name: name}
+		if err := tx.Create(&cat).Error; err != nil {
+			return nil, err
+		}
+		categories = append(categories, cat)
+	}
+	return categories, nil
+}
+
+// linkCategories: 식당에 카테고리 연결 (이미 연결된 것은 무시)
+func linkCategories(tx *gorm.DB, restaurantID uint, categories []Category) error {
+	if len(categories) == 0 {
+		return nil
+	}
+	rows := make([]map[string]any, len(categories))
+	for i, cat := range categories {
+		rows[i] = map[string]any{"restaurant_id": restaurantID, "category_id": cat.ID}
+	}
+	return tx.Table("restaurant_categories").Clauses(clause.OnConflict{DoNothing: true}).Create(rows).Error
+}
+
+// setRestaurantCategories: Food 문자열 기준으로 식당의 카테고리 연결을 다시 설정
+func setRestaurantCategories(tx *gorm.DB, restaurantID uint, food string) error {
+	if err := tx.Exec("DELETE FROM restaurant_categories WHERE restaurant_id = ?", restaurantID).Error; err != nil {
+		return err
+	}
+	categories, err := findOrCreateCategories(tx, splitFood(food))
+	if err != nil {
+		return err
+	}
+	return linkCategories(tx, restaurantID, categories)
+}
+
+// migrateFoodCategories: 카테고리가 연결되지 않은 식당의 Food 문자열을 태그로 분리해 연결
+func migrateFoodCategories(db *gorm.DB) {
+	var list []Restaurant
+	if err := db.Where("id NOT IN (SELECT restaurant_id FROM restaurant_categories)").Find(&list).Error; err != nil {
+		log.Println("ERROR 카테고리 전환 대상 조회 실패:", err)
+		return
+	}
+	if len(list) == 0 {
+		return
+	}
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		for _, res := range list {
+			categories, err := findOrCreateCategories(tx, splitFood(res.Food))
+			if err != nil {
+				return err
+			}
+			if err := linkCategories(tx, res.ID, categories); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		log.Println("ERROR 카테고리 전환 실패:", err)
+		return
+	}
+	log.Printf("INFO  식당 %d곳의 Food를 카테고리로 전환\n", len(list))
+}
+
+// filterByCategories: 카테고리 이름(쉼표로 여러 개) 중 하나라도 가진 식당만 남김
+func filterByCategories(query *gorm.DB, category string) *gorm.DB {
+	names := splitFood(category)
+	if len(names) == 0 {
+		return query
+	}
+	return query.Where(`restaurants.id IN (
+SELECT restaurant_categories.restaurant_id FROM restaurant_categories
+JOIN categories ON categories.id = restaurant_categories.category_id
+WHERE categories.name IN ?)`, names)
+}
+
+// ListCategories: 카테고리별 식당 수 (많은 순)
+func ListCategories(db *gorm.DB) ([]CategoryCount, error) {
+	list := []CategoryCount{}
+	err := db.Table("categories").
+		Select("categories.id, categories.name, COUNT(restaurants.id) AS count").
+		Joins("LEFT JOIN restaurant_categories ON restaurant_categories.category_id = categories.id").
+		Joins("LEFT JOIN restaurants ON restaurants.id = restaurant_categories.restaurant_id AND restaurants.deleted_at IS NULL").
+		Group("categories.id").
+		Order("count DESC, categories.name ASC").
+		Scan(&list).Error
+	return list, err
+}
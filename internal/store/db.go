@@ -0,0 +1,101 @@
+// Package store: SQLite(GORM) 모델과 저장/조회 함수. 모든 함수는 사용할 *gorm.DB(또는 트랜잭션)를 인자로 받는다
+package store
+
+import (
+	"time"
+
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+)
+
+type Restaurant struct {
+	gorm.Model
+	Title       string  `json:"title"`
+	Addr        string  `json:"addr"`
+	Food        string  `json:"food"`
+	X           float64 `json:"x"`
+	Y           float64 `json:"y"`
+	URL         string  `json:"url"`
+	PlaceID     *string `json:"place_id" gorm:"uniqueIndex"`   // 카카오 장소 ID (URL에서 추출)
+	AvgRating   float64 `json:"avg_rating" gorm:"default:0"`   // 평균 별점
+	RatingCount int     `json:"rating_count" gorm:"default:0"` // 참여 인원
+
+	Categories []Category    `json:"categories" gorm:"many2many:restaurant_categories"` // 음식 분류 태그
+	Hours      []OpeningHour `json:"hours"`                                             // 요일별 영업시간
+	Closures   []Closure     `json:"closures"`                                          // 오늘 이후 임시 휴무일
+}
+
+// 음식 분류 태그 (Food 문자열을 정규화)
+type Category struct {
+	ID   uint   `json:"id" gorm:"primarykey"`
+	Name string `json:"name" gorm:"uniqueIndex"`
+}
+
+// 카카오 로그인 사용자
+type User struct {
+	gorm.Model
+	KakaoID  int64  `json:"kakao_id" gorm:"uniqueIndex"` // 카카오 고유 ID
+	Nickname string `json:"nickname"`
+	Role     string `json:"role" gorm:"default:user"` // user 또는 admin
+}
+
+// 별점 기록 테이블 (식당당 사용자 1건)
+type Rating struct {
+	gorm.Model
+	RestaurantID uint    `json:"restaurant_id" gorm:"uniqueIndex:idx_rating_restaurant_user"`
+	UserID       uint    `json:"user_id" gorm:"uniqueIndex:idx_rating_restaurant_user"` // users.id
+	User         User    `json:"-"`
+	Score        float64 `json:"score"` // 1~5, 0.5점 단위
+}
+
+// 별점에 딸린 리뷰 (별점당 1건)
+type Review struct {
+	gorm.Model
+	RatingID uint       `json:"rating_id" gorm:"uniqueIndex"`
+	Rating   Rating     `json:"-"`
+	Body     string     `json:"body"`
+	Pros     []string   `json:"pros" gorm:"serializer:json"` // 장점 태그
+	Cons     []string   `json:"cons" gorm:"serializer:json"` // 단점 태그
+	EditedAt *time.Time `json:"edited_at"`                   // 마지막 수정 시각
+}
+
+// Open: SQLite DB를 열고 마이그레이션/검색 색인까지 준비 (path가 :memory:면 메모리 DB)
+func Open(path string) (*gorm.DB, error) {
+	// 동시 평가 시 잠금 대기 + 트랜잭션 시작 시점에 쓰기 잠금 확보
+	db, err := gorm.Open(sqlite.Open(path+"?_pragma=busy_timeout(5000)&_txlock=immediate"), &gorm.Config{})
+	if err != nil {
+		return nil, err
+	}
+	if path == ":memory:" {
+		// 메모리 DB는 연결마다 따로 생기므로 연결 하나만 사용
+		sqlDB, err := db.DB()
+		if err != nil {
+			return nil, err
+		}
+		sqlDB.SetMaxOpenConns(1)
+	}
+
+	// 유니크 인덱스 생성 전에 중복 별점 정리
+	dedupeRatings(db)
+	// 닉네임 기반 별점을 사용자 테이블 기반으로 전환
+	migrateLegacyRatings(db)
+	// URL에 들어 있던 카카오 장소 ID를 별도 컬럼으로 분리 (유니크 인덱스 생성 전)
+	migratePlaceIDs(db)
+
+	// 전체 테이블 마이그레이션
+	if err := db.AutoMigrate(
+		&User{}, &Restaurant{}, &Category{}, &Rating{}, &Review{}, &SessionRecord{}, &SeedVersion{},
+		&OpeningHour{}, &Closure{}, &MenuItem{},
+		&Favorite{}, &RestaurantList{}, &ListItem{},
+		&Room{}, &RoomMember{}, &RoomCandidate{}, &RoomVote{},
+	); err != nil {
+		return nil, err
+	}
+
+	// Food 문자열을 카테고리 태그로 전환
+	migrateFoodCategories(db)
+
+	// 검색 색인 생성 및 갱신
+	initSearchIndex(db)
+	return db, nil
+}
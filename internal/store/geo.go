@@ -0,0 +1,102 @@
+package store
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"strconv"
+
+	"gorm.io/gorm"
+)
+
+const (
+	earthRadius = 6371000.0 // 지구 반지름 (m)
+	MaxRadius   = 5000.0    // 검색 반경 최대값 (m)
+)
+
+var ErrInvalidGeo = errors.New("invalid lat/lng/radius")
+
+// RestaurantResult: 목록 응답 항목 (기준 좌표가 있으면 거리, 검색어가 있으면 스니펫 포함)
+type RestaurantResult struct {
+	Restaurant
+	Distance   *float64          `json:"distance,omitempty"`   // 기준 좌표로부터의 거리 (m)
+	Highlights map[string]string `json:"highlights,omitempty"` // 검색어 일치 부분 (필드별 스니펫)
+}
+
+// GeoFilter: 기준 좌표(lat, lng)와 반경(radius, m). Radius가 0이면 거리 계산만 한다.
+type GeoFilter struct {
+	Lat, Lng, Radius float64
+}
+
+// ParseGeoFilter: lat/lng/radius 쿼리 값. lat/lng가 없으면 nil
+func ParseGeoFilter(latStr, lngStr, radiusStr string) (*GeoFilter, error) {
+	if latStr == "" && lngStr == "" {
+		if radiusStr != "" {
+			return nil, ErrInvalidGeo
+		}
+		return nil, nil
+	}
+
+	lat, err1 := strconv.ParseFloat(latStr, 64)
+	lng, err2 := strconv.ParseFloat(lngStr, 64)
+	if err1 != nil || err2 != nil || lat < -90 || lat > 90 || lng < -180 || lng > 180 {
+		return nil, ErrInvalidGeo
+	}
+
+	g := &GeoFilter{Lat: lat, Lng: lng}
+	if radiusStr != "" {
+		radius, err := strconv.ParseFloat(radiusStr, 64)
+		if err != nil || radius <= 0 || radius > MaxRadius {
+			return nil, ErrInvalidGeo
+		}
+		g.Radius = radius
+	}
+	return g, nil
+}
+
+// apply: 반경을 감싸는 사각형으로 1차 필터링 (정확한 거리는 withDistance에서 계산)
+func (g *GeoFilter) apply(query *gorm.DB) *gorm.DB {
+	if g == nil || g.Radius == 0 {
+		return query
+	}
+	dLat := g.Radius / earthRadius * 180 / math.Pi
+	dLng := dLat / math.Cos(g.Lat*math.Pi/180)
+	return query.Where("y BETWEEN ? AND ? AND x BETWEEN ? AND ?", g.Lat-dLat, g.Lat+dLat, g.Lng-dLng, g.Lng+dLng)
+}
+
+// withDistance: 거리를 계산해 붙이고 반경 밖의 식당은 제외
+func (g *GeoFilter) withDistance(list []Restaurant) []RestaurantResult {
+	results := make([]RestaurantResult, 0, len(list))
+	for _, res := range list {
+		item := RestaurantResult{Restaurant: res}
+		if g != nil {
+			d := math.Round(haversine(g.Lat, g.Lng, res.Y, res.X))
+			if g.Radius > 0 && d > g.Radius {
+				continue
+			}
+			item.Distance = &d
+		}
+		results = append(results, item)
+	}
+	return results
+}
+
+// sortByDistance: 가까운 순으로 정렬 (거리가 없으면 그대로)
+func sortByDistance(results []RestaurantResult) {
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Distance == nil || results[j].Distance == nil {
+			return false
+		}
+		return *results[i].Distance < *results[j].Distance
+	})
+}
+
+// haversine: 두 위경도 좌표 사이의 거리 (m)
+func haversine(lat1, lng1, lat2, lng2 float64) float64 {
+	toRad := func(d float64) float64 { return d * math.Pi / 180 }
+	dLat := toRad(lat2 - lat1)
+	dLng := toRad(lng2 - lng1)
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(toRad(lat1))*math.Cos(toRad(lat2))*math.Sin(dLng/2)*math.Sin(dLng/2)
+	return 2 * earthRadius * math.Asin(math.Sqrt(a))
+}
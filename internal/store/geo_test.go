@@ -0,0 +1,40 @@
+package store
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseGeoFilter(t *testing.T) {
+	tests := []struct {
+		lat, lng, radius string
+		want             *GeoFilter
+		err              error
+	}{
+		{"", "", "", nil, nil},
+		{"", "", "500", nil, ErrInvalidGeo}, // 기준 좌표 없이 반경만
+		{"37.38", "126.93", "", &GeoFilter{Lat: 37.38, Lng: 126.93}, nil},
+		{"37.38", "126.93", "500", &GeoFilter{Lat: 37.38, Lng: 126.93, Radius: 500}, nil},
+		{"37.38", "126.93", "5000", &GeoFilter{Lat: 37.38, Lng: 126.93, Radius: MaxRadius}, nil},
+		{"-90", "180", "", &GeoFilter{Lat: -90, Lng: 180}, nil},
+		{"37.38", "", "", nil, ErrInvalidGeo},
+		{"", "126.93", "", nil, ErrInvalidGeo},
+		{"abc", "126.93", "", nil, ErrInvalidGeo},
+		{"91", "126.93", "", nil, ErrInvalidGeo},
+		{"37.38", "-181", "", nil, ErrInvalidGeo},
+		{"37.38", "126.93", "0", nil, ErrInvalidGeo},
+		{"37.38", "126.93", "-1", nil, ErrInvalidGeo},
+		{"37.38", "126.93", "5001", nil, ErrInvalidGeo},
+		{"37.38", "126.93", "abc", nil, ErrInvalidGeo},
+	}
+	for _, tt := range tests {
+		got, err := ParseGeoFilter(tt.lat, tt.lng, tt.radius)
+		if !errors.Is(err, tt.err) {
+			t.Errorf("ParseGeoFilter(%q, %q, %q) error = %v, want %v", tt.lat, tt.lng, tt.radius, err, tt.err)
+			continue
+		}
+		if (got == nil) != (tt.want == nil) || (got != nil && *got != *tt.want) {
+			t.Errorf("ParseGeoFilter(%q, %q, %q) = %+v, want %+v", tt.lat, tt.lng, tt.radius, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,135 @@
+package store
+
+import "strings"
+
+const (
+	hangulBase = 0xAC00 // '가'
+	hangulLast = 0xD7A3 // '힣'
+)
+
+// 초성 19자 (호환 자모)
+var chosungs = []rune("ㄱㄲㄴㄷㄸㄹㅁㅂㅃㅅㅆㅇㅈㅉㅊㅋㅌㅍㅎ")
+
+// 흔한 맞춤법 변형을 한 가지로 맞춤. 글자 수가 바뀌지 않는 쌍만 넣어야 스니펫 위치가 어긋나지 않는다.
+var spellingVariants = strings.NewReplacer(
+	"까스", "가스",
+	"까페", "카페",
+	"자장", "짜장",
+	"쭈꾸미", "주꾸미",
+	"찌게", "찌개",
+	"순댓국", "순대국",
+	"떡볶기", "떡볶이",
+	"떡뽁이", "떡볶이",
+	"떡복이", "떡볶이",
+	"닭도리", "닭볶음",
+)
+
+// normalizeSpelling: 맞춤법 변형을 표준 표기로 바꿈
+func normalizeSpelling(s string) string {
+	return spellingVariants.Replace(s)
+}
+
+func isHangulSyllable(r rune) bool {
+	return r >= hangulBase && r <= hangulLast
+}
+
+// isChosung: 호환 자모 자음(ㄱ~ㅎ 중 초성으로 쓰이는 글자)인지
+func isChosung(r rune) bool {
+	for _, c := range chosungs {
+		if c == r {
+			return true
+		}
+	}
+	return false
+}
+
+// chosungOf: 음절의 초성 (한글 음절이 아니면 그대로)
+func chosungOf(r rune) rune {
+	if !isHangulSyllable(r) {
+		return r
+	}
+	return chosungs[(r-hangulBase)/588]
+}
+
+// isChosungQuery: 초성만으로 된 검색어인지 ("ㄱㅂ", "ㄷㄱ ㅅ")
+func isChosungQuery(s string) bool {
+	found := false
+	for _, r := range s {
+		switch {
+		case r == ' ':
+		case isChosung(r):
+			found = true
+		default:
+			return false
+		}
+	}
+	return found
+}
+
+// syllablePrefix: q가 t를 입력하는 도중의 글자인지 (받침 없는 "바"는 "밥"의 앞부분)
+func syllablePrefix(q, t rune) bool {
+	if !isHangulSyllable(q) || !isHangulSyllable(t) || (q-hangulBase)%28 != 0 {
+		return false
+	}
+	return (q-hangulBase)/28 == (t-hangulBase)/28
+}
+
+// runeMatches: 검색어 글자 q가 대상 글자 t와 맞는지 (초성만 입력한 경우 포함)
+func runeMatches(q, t rune, last bool) bool {
+	if q == t {
+		return true
+	}
+	if isChosung(q) && chosungOf(t) == q {
+		return true
+	}
+	return last && syllablePrefix(q, t)
+}
+
+// hangulIndex: text 안에서 query와 맞는 첫 위치 (없으면 -1).
+// 각 글자는 같거나 초성이 같으면 맞고, 마지막 글자는 입력 중인 음절("김바" → "김밥")도 허용한다.
+func hangulIndex(text, query []rune) int {
+	if len(query) == 0 {
+		return -1
+	}
+	for i := 0; i+len(query) <= len(text); i++ {
+		ok := true
+		for j, q := range query {
+			if !runeMatches(q, text[i+j], j == len(query)-1) {
+				ok = false
+				break
+			}
+		}
+		if ok {
+			return i
+		}
+	}
+	return -1
+}
+
+// typoDistance: query와 text의 같은 길이 구간 중 가장 가까운 편집 거리 (±1글자 길이 차이 포함)
+func typoDistance(text, query []rune) int {
+	best := len(query)
+	for size := len(query) - 1; size <= len(query)+1; size++ {
+		if size <= 0 || size > len(text) {
+			continue
+		}
+		for i := 0; i+size <= len(text); i++ {
+			if d := editDistance(text[i:i+size], query); d < best {
+				best = d
+			}
+		}
+	}
+	return best
+}
+
+// maxTypos: 검색어 길이에 따라 허용하는 오타 수 (두 글자 이하는 오타를 허용하지 않음)
+func maxTypos(query []rune) int {
+	switch {
+	case len(query) <= 2:
+		return 0
+	case len(query) <= 5:
+		return 1
+	default:
+		return 2
+	}
+}
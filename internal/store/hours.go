@@ -0,0 +1,204 @@
+package store
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	hourLayout = "15:04"      // 영업시간 표기 (HH:MM)
+	dateLayout = "2006-01-02" // 휴무일 표기
+)
+
+var ErrInvalidOpenAt = errors.New("invalid open_now/open_at")
+
+// 영업 여부는 항상 한국 시간으로 판단
+var seoul = loadSeoul()
+
+func loadSeoul() *time.Location {
+	if loc, err := time.LoadLocation("Asia/Seoul"); err == nil {
+		return loc
+	}
+	return time.FixedZone("KST", 9*60*60) // tzdata가 없는 환경 (서머타임 없음)
+}
+
+// 요일별 영업시간. 마감이 개점보다 이르거나 같으면 다음 날 새벽까지 영업 (예: 17:00~02:00, 00:00~00:00은 24시간)
+type OpeningHour struct {
+	ID           uint   `json:"-" gorm:"primarykey"`
+	RestaurantID uint   `json:"-" gorm:"index"`
+	Weekday      int    `json:"weekday" minimum:"0" maximum:"6"` // 0=일요일 ... 6=토요일
+	Open         string `json:"open" required:"true"`            // 개점 시각 (HH:MM)
+	Close        string `json:"close" required:"true"`           // 마감 시각 (HH:MM)
+	BreakStart   string `json:"break_start,omitempty"`           // 브레이크 타임 시작 (없으면 빈 값)
+	BreakEnd     string `json:"break_end,omitempty"`             // 브레이크 타임 끝
+}
+
+// 임시 휴무일 (정기 영업시간보다 우선)
+type Closure struct {
+	ID           uint   `json:"-" gorm:"primarykey"`
+	RestaurantID uint   `json:"-" gorm:"uniqueIndex:idx_closure_restaurant_date"`
+	Date         string `json:"date" required:"true" gorm:"uniqueIndex:idx_closure_restaurant_date"` // YYYY-MM-DD
+	Reason       string `json:"reason,omitempty"`
+}
+
+// HoursInput: 관리자 영업시간/휴무일 설정 요청 (기존 값을 통째로 교체)
+type HoursInput struct {
+	Hours    []OpeningHour `json:"hours"`
+	Closures []Closure     `json:"closures"`
+}
+
+// Validate: 필드별 오류 코드 (문제가 없으면 빈 맵, 메시지는 handler가 언어에 맞게 만든다)
+func (in *HoursInput) Validate() map[string]string {
+	errs := map[string]string{}
+	for i := range in.Hours {
+		h := &in.Hours[i]
+		key := fmt.Sprintf("hours[%d]", i)
+		h.Open, h.Close = strings.TrimSpace(h.Open), strings.TrimSpace(h.Close)
+		h.BreakStart, h.BreakEnd = strings.TrimSpace(h.BreakStart), strings.TrimSpace(h.BreakEnd)
+
+		if h.Weekday < 0 || h.Weekday > 6 {
+			errs[key+".weekday"] = "hours.weekday.range"
+		}
+		if !validHour(h.Open) {
+			errs[key+".open"] = "hours.open.format"
+		}
+		if !validHour(h.Close) {
+			errs[key+".close"] = "hours.close.format"
+		}
+		if h.BreakStart == "" && h.BreakEnd == "" {
+			continue
+		}
+		if !validHour(h.BreakStart) || !validHour(h.BreakEnd) || h.BreakStart >= h.BreakEnd {
+			errs[key+".break"] = "hours.break.format"
+		}
+	}
+
+	seen := map[string]bool{}
+	for i := range in.Closures {
+		cl := &in.Closures[i]
+		key := fmt.Sprintf("closures[%d]", i)
+		cl.Date, cl.Reason = strings.TrimSpace(cl.Date), strings.TrimSpace(cl.Reason)
+		if _, err := time.Parse(dateLayout, cl.Date); err != nil {
+			errs[key+".date"] = "closures.date.format"
+		} else if seen[cl.Date] {
+			errs[key+".date"] = "closures.date.duplicate"
+		}
+		seen[cl.Date] = true
+	}
+	return errs
+}
+
+// validHour: 00:00~23:59 형식 검사 (문자열 비교로 시각을 비교하므로 두 자리로 맞춰야 함)
+func validHour(s string) bool {
+	t, err := time.Parse(hourLayout, s)
+	return err == nil && t.Format(hourLayout) == s
+}
+
+// SetRestaurantHours: 식당의 영업시간/휴무일을 입력 값으로 교체
+func SetRestaurantHours(db *gorm.DB, restaurantID uint, in HoursInput) (Restaurant, error) {
+	var res Restaurant
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.First(&res, restaurantID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrRestaurantNotFound
+			}
+			return err
+		}
+		if err := tx.Where("restaurant_id = ?", restaurantID).Delete(&OpeningHour{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("restaurant_id = ?", restaurantID).Delete(&Closure{}).Error; err != nil {
+			return err
+		}
+		for _, h := range in.Hours {
+			h.ID, h.RestaurantID = 0, restaurantID
+			if err := tx.Create(&h).Error; err != nil {
+				return err
+			}
+		}
+		for _, cl := range in.Closures {
+			cl.ID, cl.RestaurantID = 0, restaurantID
+			if err := tx.Create(&cl).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return res, err
+	}
+	return res, preloadDetails(db).First(&res, restaurantID).Error
+}
+
+// preloadDetails: 식당 응답에 필요한 카테고리, 영업시간, 오늘 이후 휴무일을 함께 읽음
+func preloadDetails(db *gorm.DB) *gorm.DB {
+	today := time.Now().In(seoul).Format(dateLayout)
+	return db.Preload("Categories").
+		Preload("Hours", func(db *gorm.DB) *gorm.DB { return db.Order("weekday, open") }).
+		Preload("Closures", func(db *gorm.DB) *gorm.DB { return db.Where("date >= ?", today).Order("date") })
+}
+
+// ParseOpenAt: open_now=true 또는 open_at 쿼리 값. 둘 다 없으면 nil.
+// open_at은 HH:MM(오늘), YYYY-MM-DDTHH:MM(한국 시간) 또는 RFC3339 형식
+func ParseOpenAt(openNow, openAt string) (*time.Time, error) {
+	if openNow != "" && openAt != "" {
+		return nil, ErrInvalidOpenAt
+	}
+	if openNow != "" {
+		v, err := strconv.ParseBool(openNow)
+		if err != nil {
+			return nil, ErrInvalidOpenAt
+		}
+		if !v {
+			return nil, nil
+		}
+		now := time.Now().In(seoul)
+		return &now, nil
+	}
+	if openAt == "" {
+		return nil, nil
+	}
+
+	if t, err := time.Parse(time.RFC3339, openAt); err == nil {
+		t = t.In(seoul)
+		return &t, nil
+	}
+	if t, err := time.ParseInLocation("2006-01-02T15:04", openAt, seoul); err == nil {
+		return &t, nil
+	}
+	if validHour(openAt) {
+		now := time.Now().In(seoul)
+		hm, _ := time.Parse(hourLayout, openAt)
+		t := time.Date(now.Year(), now.Month(), now.Day(), hm.Hour(), hm.Minute(), 0, 0, seoul)
+		return &t, nil
+	}
+	return nil, ErrInvalidOpenAt
+}
+
+// openAtCondition: t(한국 시간)에 영업 중인 식당만 남기는 조건.
+// 당일 영업시간 또는 전날 새벽까지 이어지는 영업시간에 포함되고, 브레이크 타임과 해당 영업일의 휴무일이 아니어야 한다.
+// 영업시간이 등록되지 않은 식당은 영업 여부를 알 수 없으므로 제외된다.
+func openAtCondition(query *gorm.DB, t time.Time) *gorm.DB {
+	t = t.In(seoul)
+	prev := t.AddDate(0, 0, -1)
+	return query.Where(`EXISTS (
+		SELECT 1 FROM opening_hours h WHERE h.restaurant_id = restaurants.id AND (
+			(h.weekday = @day AND h.open <= @hm AND (h.close > @hm OR h.close <= h.open)
+				AND NOT EXISTS (SELECT 1 FROM closures c WHERE c.restaurant_id = restaurants.id AND c.date = @date))
+			OR (h.weekday = @prev_day AND h.close <= h.open AND h.close > @hm
+				AND NOT EXISTS (SELECT 1 FROM closures c WHERE c.restaurant_id = restaurants.id AND c.date = @prev_date))
+		) AND NOT (h.break_start <> '' AND h.break_start <= @hm AND @hm < h.break_end)
+	)`,
+		sql.Named("day", int(t.Weekday())),
+		sql.Named("prev_day", int(prev.Weekday())),
+		sql.Named("hm", t.Format(hourLayout)),
+		sql.Named("date", t.Format(dateLayout)),
+		sql.Named("prev_date", prev.Format(dateLayout)),
+	)
+}
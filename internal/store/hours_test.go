@@ -0,0 +1,68 @@
+package store
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestOpenAtCondition(t *testing.T) {
+	db := newTestDB(t)
+	lunch := addRestaurant(t, db, 1, "점심 식당", "한식")
+	pub := addRestaurant(t, db, 2, "심야 주점", "술집")
+	allDay := addRestaurant(t, db, 3, "24시 국밥", "한식")
+	addRestaurant(t, db, 4, "시간 미등록", "한식") // 영업 여부를 알 수 없으므로 항상 제외
+
+	everyDay := make([]OpeningHour, 7)
+	for i := range everyDay {
+		everyDay[i] = OpeningHour{Weekday: i, Open: "00:00", Close: "00:00"}
+	}
+	hours := map[uint]HoursInput{
+		// 월요일 11:00~21:00, 15:00~17:00 브레이크 타임, 2026-10-26(월) 휴무
+		lunch.ID: {
+			Hours:    []OpeningHour{{Weekday: 1, Open: "11:00", Close: "21:00", BreakStart: "15:00", BreakEnd: "17:00"}},
+			Closures: []Closure{{Date: "2026-10-26"}},
+		},
+		// 금요일 18:00~다음 날 02:00, 2026-10-30(금) 휴무
+		pub.ID: {
+			Hours:    []OpeningHour{{Weekday: 5, Open: "18:00", Close: "02:00"}},
+			Closures: []Closure{{Date: "2026-10-30"}},
+		},
+		allDay.ID: {Hours: everyDay},
+	}
+	for id, in := range hours {
+		if _, err := SetRestaurantHours(db, id, in); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name string
+		at   time.Time
+		want []string
+	}{
+		{"개점 전", time.Date(2026, 10, 19, 10, 59, 0, 0, seoul), []string{"24시 국밥"}},
+		{"영업 중", time.Date(2026, 10, 19, 12, 0, 0, 0, seoul), []string{"점심 식당", "24시 국밥"}},
+		{"브레이크 타임", time.Date(2026, 10, 19, 15, 30, 0, 0, seoul), []string{"24시 국밥"}},
+		{"브레이크 타임 끝", time.Date(2026, 10, 19, 17, 0, 0, 0, seoul), []string{"점심 식당", "24시 국밥"}},
+		{"마감 시각", time.Date(2026, 10, 19, 21, 0, 0, 0, seoul), []string{"24시 국밥"}},
+		{"휴무일", time.Date(2026, 10, 26, 12, 0, 0, 0, seoul), []string{"24시 국밥"}},
+		{"심야 영업", time.Date(2026, 10, 23, 23, 0, 0, 0, seoul), []string{"심야 주점", "24시 국밥"}},
+		{"다음 날 새벽", time.Date(2026, 10, 24, 1, 30, 0, 0, seoul), []string{"심야 주점", "24시 국밥"}},
+		{"새벽 마감 시각", time.Date(2026, 10, 24, 2, 0, 0, 0, seoul), []string{"24시 국밥"}},
+		{"휴무일 밤", time.Date(2026, 10, 30, 23, 0, 0, 0, seoul), []string{"24시 국밥"}},
+		{"휴무일 다음 날 새벽", time.Date(2026, 10, 31, 1, 0, 0, 0, seoul), []string{"24시 국밥"}},
+		{"다른 시간대", time.Date(2026, 10, 19, 3, 0, 0, 0, time.UTC), []string{"점심 식당", "24시 국밥"}}, // 한국 시간 12:00
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			if err := openAtCondition(db.Model(&Restaurant{}), tt.at).Order("id").Pluck("title", &got).Error; err != nil {
+				t.Fatal(err)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("open at %s = %v, want %v", tt.at, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package store
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+var ErrInvalidSort = errors.New("invalid sort")
+
+// RestaurantFilter: 목록/추천 공통 필터
+type RestaurantFilter struct {
+	Category  string     // 카테고리 이름 (쉼표로 여러 개)
+	Search    string     // 검색어 (이름, 음식 종류, 주소, 메뉴)
+	Geo       *GeoFilter // 기준 좌표와 반경
+	MinRating float64    // 최소 평균 별점
+	Exclude   []uint     // 제외할 식당 ID
+	OpenAt    *time.Time // 이 시각(한국 시간)에 영업 중인 식당만
+	Price     PriceRange // 이 가격대의 대표 메뉴가 있는 식당만
+}
+
+// apply: 좌표를 제외한 필터를 쿼리에 적용 (좌표는 GeoFilter가 처리)
+func (f RestaurantFilter) apply(query *gorm.DB) *gorm.DB {
+	if f.Category != "" && f.Category != "all" {
+		query = filterByCategories(query, f.Category)
+	}
+	if f.Search != "" {
+		query = applySearch(query, f.Search)
+	}
+	if f.MinRating > 0 {
+		query = query.Where("avg_rating >= ?", f.MinRating)
+	}
+	if len(f.Exclude) > 0 {
+		query = query.Where("restaurants.id NOT IN ?", f.Exclude)
+	}
+	if f.OpenAt != nil {
+		query = openAtCondition(query, *f.OpenAt)
+	}
+	return f.Price.apply(query)
+}
+
+// 맛집 목록 정렬 기준 (distance는 기준 좌표, relevance는 검색어가 있을 때만 사용 가능)
+var restaurantSorts = map[string]string{
+	"":             "id ASC",
+	"rating":       "avg_rating DESC, rating_count DESC, id ASC",
+	"rating_count": "rating_count DESC, avg_rating DESC, id ASC",
+	"name":         "title ASC, id ASC",
+	"newest":       "created_at DESC, id DESC",
+	"distance":     "id ASC",
+	"relevance":    "search_rank ASC, id ASC",
+}
+
+// ListRestaurants: 필터/정렬/페이지 적용한 맛집 목록과 전체 개수. 검색어가 있으면 기본 정렬은 관련도 순.
+// 기준 좌표가 있으면 반경 필터와 거리 정렬을 위해 후보 전체를 읽은 뒤 메모리에서 자른다.
+func ListRestaurants(db *gorm.DB, f RestaurantFilter, sort string, limit, offset int) ([]RestaurantResult, int64, error) {
+	geo, searching := f.Geo, len(searchWords(f.Search)) > 0
+	if sort == "" && searching {
+		sort = "relevance"
+	}
+	order, ok := restaurantSorts[sort]
+	if !ok || (sort == "distance" && geo == nil) || (sort == "relevance" && !searching) {
+		return nil, 0, ErrInvalidSort
+	}
+	query := geo.apply(f.apply(db.Model(&Restaurant{}))).Order(order)
+	rows := preloadDetails(query.Session(&gorm.Session{}))
+
+	if geo != nil {
+		var list []Restaurant
+		if err := rows.Find(&list).Error; err != nil {
+			return nil, 0, err
+		}
+		results := geo.withDistance(list)
+		if sort == "distance" {
+			sortByDistance(results)
+		}
+		total := int64(len(results))
+		if offset >= len(results) {
+			return []RestaurantResult{}, total, nil
+		}
+		return results[offset:min(offset+limit, len(results))], total, nil
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var list []Restaurant
+	if err := rows.Limit(limit).Offset(offset).Find(&list).Error; err != nil {
+		return nil, 0, err
+	}
+	return geo.withDistance(list), total, nil
+}
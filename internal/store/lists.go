@@ -0,0 +1,320 @@
+package store
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	MaxListsPerUser = 50  // 사용자당 목록 수
+	MaxListItems    = 200 // 목록당 식당 수
+)
+
+var (
+	ErrListNotFound     = errors.New("list not found")
+	ErrInvalidListOrder = errors.New("invalid list order")
+	ErrTooManyLists     = errors.New("too many lists")
+	ErrTooManyListItems = errors.New("too many list items")
+)
+
+// 즐겨찾기 (사용자당 식당 1건)
+type Favorite struct {
+	UserID       uint      `json:"-" gorm:"primaryKey"`
+	RestaurantID uint      `json:"restaurant_id" gorm:"primaryKey"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// 사용자가 만든 식당 목록 ("비 오는 날", "회식 후보" 등)
+type RestaurantList struct {
+	ID         uint      `json:"id" gorm:"primarykey"`
+	UserID     uint      `json:"-" gorm:"index"`
+	Name       string    `json:"name"`
+	Shared     bool      `json:"shared"`                                   // 링크가 있으면 누구나 볼 수 있음
+	ShareToken string    `json:"share_token,omitempty" gorm:"uniqueIndex"` // 공유 링크용 토큰
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// 목록에 담긴 식당 (Position 순으로 표시)
+type ListItem struct {
+	ListID       uint `gorm:"primaryKey"`
+	RestaurantID uint `gorm:"primaryKey"`
+	Position     int
+	CreatedAt    time.Time
+}
+
+// ListSummary: 내 목록 응답 항목
+type ListSummary struct {
+	RestaurantList
+	ItemCount int64 `json:"item_count"`
+}
+
+// ListDetail: 목록과 담긴 식당 전체 정보
+type ListDetail struct {
+	RestaurantList
+	Owner       string       `json:"owner"` // 만든 사람 닉네임
+	Restaurants []Restaurant `json:"restaurants"`
+}
+
+// ListInput: 목록 생성/수정 요청
+type ListInput struct {
+	Name   string `json:"name" required:"true" maxLength:"30"`
+	Shared bool   `json:"shared"`
+}
+
+// Validate: 필드별 오류 코드 (문제가 없으면 빈 맵, 메시지는 handler가 언어에 맞게 만든다)
+func (in *ListInput) Validate() map[string]string {
+	in.Name = strings.TrimSpace(in.Name)
+	errs := map[string]string{}
+	if in.Name == "" || utf8.RuneCountInString(in.Name) > 30 {
+		errs["name"] = "list_name.length"
+	}
+	return errs
+}
+
+// findRestaurant: 삭제되지 않은 식당인지 확인
+func findRestaurant(db *gorm.DB, restaurantID uint) error {
+	err := db.Select("id").First(&Restaurant{}, restaurantID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrRestaurantNotFound
+	}
+	return err
+}
+
+// restaurantsInOrder: ID 순서대로 식당 전체 정보 조회 (삭제된 식당은 빠짐)
+func restaurantsInOrder(db *gorm.DB, ids []uint) ([]Restaurant, error) {
+	var found []Restaurant
+	if err := preloadDetails(db).Where("id IN ?", ids).Find(&found).Error; err != nil {
+		return nil, err
+	}
+	byID := make(map[uint]Restaurant, len(found))
+	for _, res := range found {
+		byID[res.ID] = res
+	}
+	list := make([]Restaurant, 0, len(found))
+	for _, id := range ids {
+		if res, ok := byID[id]; ok {
+			list = append(list, res)
+		}
+	}
+	return list, nil
+}
+
+// ListFavorites: 즐겨찾기한 식당 (최근 추가한 순)
+func ListFavorites(db *gorm.DB, userID uint) ([]Restaurant, error) {
+	var ids []uint
+	if err := db.Model(&Favorite{}).Where("user_id = ?", userID).Order("created_at DESC").Pluck("restaurant_id", &ids).Error; err != nil {
+		return nil, err
+	}
+	return restaurantsInOrder(db, ids)
+}
+
+// AddFavorite: 즐겨찾기 추가 (이미 있으면 그대로)
+func AddFavorite(db *gorm.DB, userID, restaurantID uint) error {
+	if err := findRestaurant(db, restaurantID); err != nil {
+		return err
+	}
+	return db.Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&Favorite{UserID: userID, RestaurantID: restaurantID}).Error
+}
+
+// RemoveFavorite: 즐겨찾기 해제 (없어도 성공)
+func RemoveFavorite(db *gorm.DB, userID, restaurantID uint) error {
+	return db.Where("user_id = ? AND restaurant_id = ?", userID, restaurantID).Delete(&Favorite{}).Error
+}
+
+// newShareToken: 공유 링크용 임의 토큰
+func newShareToken() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// MyLists: 내 목록과 담긴 식당 수
+func MyLists(db *gorm.DB, userID uint) ([]ListSummary, error) {
+	lists := []ListSummary{}
+	err := db.Model(&RestaurantList{}).
+		Select("restaurant_lists.*, (SELECT COUNT(*) FROM list_items WHERE list_items.list_id = restaurant_lists.id) AS item_count").
+		Where("user_id = ?", userID).
+		Order("id").
+		Scan(&lists).Error
+	return lists, err
+}
+
+// FindOwnList: 내 목록 조회
+func FindOwnList(db *gorm.DB, listID, userID uint) (RestaurantList, error) {
+	var list RestaurantList
+	err := db.Where("id = ? AND user_id = ?", listID, userID).First(&list).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return list, ErrListNotFound
+	}
+	return list, err
+}
+
+// CreateList: 목록 생성
+func CreateList(db *gorm.DB, userID uint, in ListInput) (RestaurantList, error) {
+	token, err := newShareToken()
+	if err != nil {
+		return RestaurantList{}, err
+	}
+	list := RestaurantList{UserID: userID, Name: in.Name, Shared: in.Shared, ShareToken: token}
+	err = db.Transaction(func(tx *gorm.DB) error {
+		var count int64
+		if err := tx.Model(&RestaurantList{}).Where("user_id = ?", userID).Count(&count).Error; err != nil {
+			return err
+		}
+		if count >= MaxListsPerUser {
+			return ErrTooManyLists
+		}
+		return tx.Create(&list).Error
+	})
+	return list, err
+}
+
+// UpdateList: 목록 이름/공유 여부 수정
+func UpdateList(db *gorm.DB, listID, userID uint, in ListInput) (RestaurantList, error) {
+	var list RestaurantList
+	err := db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		if list, err = FindOwnList(tx, listID, userID); err != nil {
+			return err
+		}
+		list.Name, list.Shared = in.Name, in.Shared
+		return tx.Select("Name", "Shared").Updates(&list).Error
+	})
+	return list, err
+}
+
+// DeleteList: 목록과 담긴 항목 삭제
+func DeleteList(db *gorm.DB, listID, userID uint) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		list, err := FindOwnList(tx, listID, userID)
+		if err != nil {
+			return err
+		}
+		if err := tx.Where("list_id = ?", list.ID).Delete(&ListItem{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&list).Error
+	})
+}
+
+// LoadListDetail: 목록과 담긴 식당 (Position 순)
+func LoadListDetail(db *gorm.DB, list RestaurantList) (ListDetail, error) {
+	detail := ListDetail{RestaurantList: list}
+	var owner User
+	if err := db.Select("nickname").Limit(1).Find(&owner, list.UserID).Error; err != nil {
+		return detail, err
+	}
+	detail.Owner = owner.Nickname
+
+	var ids []uint
+	if err := db.Model(&ListItem{}).Where("list_id = ?", list.ID).Order("position, created_at").Pluck("restaurant_id", &ids).Error; err != nil {
+		return detail, err
+	}
+	var err error
+	detail.Restaurants, err = restaurantsInOrder(db, ids)
+	return detail, err
+}
+
+// SharedList: 공유 토큰으로 목록 조회 (공유가 꺼져 있으면 찾을 수 없음)
+func SharedList(db *gorm.DB, token string) (ListDetail, error) {
+	var list RestaurantList
+	err := db.Where("share_token = ? AND shared = ?", token, true).First(&list).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ListDetail{}, ErrListNotFound
+	}
+	if err != nil {
+		return ListDetail{}, err
+	}
+	return LoadListDetail(db, list)
+}
+
+// AddListItem: 목록 맨 뒤에 식당 추가 (이미 있으면 그대로)
+func AddListItem(db *gorm.DB, listID, userID, restaurantID uint) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		if _, err := FindOwnList(tx, listID, userID); err != nil {
+			return err
+		}
+		if err := findRestaurant(tx, restaurantID); err != nil {
+			return err
+		}
+
+		var exists int64
+		if err := tx.Model(&ListItem{}).Where("list_id = ? AND restaurant_id = ?", listID, restaurantID).Count(&exists).Error; err != nil {
+			return err
+		}
+		if exists > 0 {
+			return nil
+		}
+
+		var stat struct {
+			Count int64
+			Last  int
+		}
+		if err := tx.Model(&ListItem{}).Select("COUNT(*) AS count, COALESCE(MAX(position), 0) AS last").
+			Where("list_id = ?", listID).Scan(&stat).Error; err != nil {
+			return err
+		}
+		if stat.Count >= MaxListItems {
+			return ErrTooManyListItems
+		}
+		if err := tx.Create(&ListItem{ListID: listID, RestaurantID: restaurantID, Position: stat.Last + 1}).Error; err != nil {
+			return err
+		}
+		return tx.Model(&RestaurantList{}).Where("id = ?", listID).Update("updated_at", time.Now()).Error
+	})
+}
+
+// RemoveListItem: 목록에서 식당 제거 (없어도 성공)
+func RemoveListItem(db *gorm.DB, listID, userID, restaurantID uint) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		if _, err := FindOwnList(tx, listID, userID); err != nil {
+			return err
+		}
+		return tx.Where("list_id = ? AND restaurant_id = ?", listID, restaurantID).Delete(&ListItem{}).Error
+	})
+}
+
+// ReorderList: 담긴 식당 ID 전체를 원하는 순서로 보내면 그 순서대로 저장
+func ReorderList(db *gorm.DB, listID, userID uint, order []uint) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		if _, err := FindOwnList(tx, listID, userID); err != nil {
+			return err
+		}
+		var current []uint
+		if err := tx.Model(&ListItem{}).Where("list_id = ?", listID).Pluck("restaurant_id", &current).Error; err != nil {
+			return err
+		}
+		if len(order) != len(current) {
+			return ErrInvalidListOrder
+		}
+		inList := make(map[uint]bool, len(current))
+		for _, id := range current {
+			inList[id] = true
+		}
+		for _, id := range order {
+			if !inList[id] {
+				return ErrInvalidListOrder // 목록에 없거나 중복된 ID
+			}
+			delete(inList, id)
+		}
+
+		for i, id := range order {
+			if err := tx.Model(&ListItem{}).Where("list_id = ? AND restaurant_id = ?", listID, id).
+				Update("position", i+1).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Model(&RestaurantList{}).Where("id = ?", listID).Update("updated_at", time.Now()).Error
+	})
+}
@@ -0,0 +1,58 @@
+package store
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestReorderList(t *testing.T) {
+	db := newTestDB(t)
+	owner, other := addUser(t, db, 1001), addUser(t, db, 1002)
+	a := addRestaurant(t, db, 1, "가야밀면", "한식")
+	b := addRestaurant(t, db, 2, "남촌김밥", "분식")
+	c := addRestaurant(t, db, 3, "더카페", "카페")
+	d := addRestaurant(t, db, 4, "신전떡볶이", "분식") // 목록에 없는 식당
+
+	list, err := CreateList(db, owner.ID, ListInput{Name: "점심"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, res := range []Restaurant{a, b, c} {
+		if err := AddListItem(db, list.ID, owner.ID, res.ID); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name  string
+		user  uint
+		order []uint
+		err   error
+		want  []string // 실패하면 이전 순서 그대로
+	}{
+		{"뒤집기", owner.ID, []uint{c.ID, b.ID, a.ID}, nil, []string{"더카페", "남촌김밥", "가야밀면"}},
+		{"일부 이동", owner.ID, []uint{b.ID, c.ID, a.ID}, nil, []string{"남촌김밥", "더카페", "가야밀면"}},
+		{"빠진 식당", owner.ID, []uint{a.ID, b.ID}, ErrInvalidListOrder, []string{"남촌김밥", "더카페", "가야밀면"}},
+		{"중복된 식당", owner.ID, []uint{a.ID, a.ID, b.ID}, ErrInvalidListOrder, []string{"남촌김밥", "더카페", "가야밀면"}},
+		{"목록에 없는 식당", owner.ID, []uint{a.ID, b.ID, d.ID}, ErrInvalidListOrder, []string{"남촌김밥", "더카페", "가야밀면"}},
+		{"남의 목록", other.ID, []uint{a.ID, b.ID, c.ID}, ErrListNotFound, []string{"남촌김밥", "더카페", "가야밀면"}},
+		{"원래 순서", owner.ID, []uint{a.ID, b.ID, c.ID}, nil, []string{"가야밀면", "남촌김밥", "더카페"}},
+	}
+	for _, tt := range tests {
+		if err := ReorderList(db, list.ID, tt.user, tt.order); !errors.Is(err, tt.err) {
+			t.Errorf("%s: error = %v, want %v", tt.name, err, tt.err)
+		}
+		detail, err := LoadListDetail(db, list)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got []string
+		for _, res := range detail.Restaurants {
+			got = append(got, res.Title)
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("%s: order = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,161 @@
+package store
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"gorm.io/gorm"
+)
+
+const MaxMenuPrice = 1000000 // 메뉴 가격 상한 (원)
+
+var (
+	ErrMenuItemNotFound = errors.New("menu item not found")
+	ErrInvalidPrice     = errors.New("invalid min_price/max_price")
+)
+
+// 식당 메뉴 (가격은 원 단위)
+type MenuItem struct {
+	ID           uint      `json:"id" gorm:"primarykey"`
+	RestaurantID uint      `json:"restaurant_id" gorm:"index"`
+	Name         string    `json:"name"`
+	Price        int       `json:"price" gorm:"index"`
+	Signature    bool      `json:"signature"` // 대표 메뉴 여부
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// MenuItemInput: 관리자 메뉴 등록/수정 요청
+type MenuItemInput struct {
+	Name      string `json:"name" required:"true" maxLength:"50"`
+	Price     int    `json:"price" required:"true" minimum:"1" maximum:"1000000"`
+	Signature bool   `json:"signature"`
+}
+
+// Validate: 필드별 오류 코드 (문제가 없으면 빈 맵, 메시지는 handler가 언어에 맞게 만든다)
+func (in *MenuItemInput) Validate() map[string]string {
+	in.Name = strings.TrimSpace(in.Name)
+
+	errs := map[string]string{}
+	if in.Name == "" || utf8.RuneCountInString(in.Name) > 50 {
+		errs["name"] = "menu_name.length"
+	}
+	if in.Price <= 0 || in.Price > MaxMenuPrice {
+		errs["price"] = "price.range"
+	}
+	return errs
+}
+
+// PriceRange: 목록 필터의 가격 범위 (0이면 제한 없음)
+type PriceRange struct {
+	Min, Max int
+}
+
+// ParsePriceRange: min_price/max_price 쿼리 값
+func ParsePriceRange(minPrice, maxPrice string) (PriceRange, error) {
+	var p PriceRange
+	for _, q := range []struct {
+		value string
+		dst   *int
+	}{{minPrice, &p.Min}, {maxPrice, &p.Max}} {
+		if q.value == "" {
+			continue
+		}
+		n, err := strconv.Atoi(q.value)
+		if err != nil || n <= 0 || n > MaxMenuPrice {
+			return p, ErrInvalidPrice
+		}
+		*q.dst = n
+	}
+	if p.Max > 0 && p.Min > p.Max {
+		return p, ErrInvalidPrice
+	}
+	return p, nil
+}
+
+// apply: 가격 범위에 드는 대표 메뉴가 하나라도 있는 식당만 남김
+// (공기밥 같은 곁들임 메뉴 가격으로 걸러지지 않도록 대표 메뉴만 비교)
+func (p PriceRange) apply(query *gorm.DB) *gorm.DB {
+	if p.Min == 0 && p.Max == 0 {
+		return query
+	}
+	sub := query.Session(&gorm.Session{NewDB: true}).Table("menu_items").Select("1").
+		Where("menu_items.restaurant_id = restaurants.id AND menu_items.signature = ?", true)
+	if p.Min > 0 {
+		sub = sub.Where("menu_items.price >= ?", p.Min)
+	}
+	if p.Max > 0 {
+		sub = sub.Where("menu_items.price <= ?", p.Max)
+	}
+	return query.Where("EXISTS (?)", sub)
+}
+
+// ListMenu: 식당 메뉴 (대표 메뉴 먼저, 가격 낮은 순)
+func ListMenu(db *gorm.DB, restaurantID uint) ([]MenuItem, error) {
+	if err := db.Select("id").First(&Restaurant{}, restaurantID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrRestaurantNotFound
+		}
+		return nil, err
+	}
+	items := []MenuItem{}
+	err := db.Where("restaurant_id = ?", restaurantID).Order("signature DESC, price ASC, id ASC").Find(&items).Error
+	return items, err
+}
+
+// CreateMenuItem: 식당에 메뉴 추가
+func CreateMenuItem(db *gorm.DB, restaurantID uint, in MenuItemInput) (MenuItem, error) {
+	item := MenuItem{RestaurantID: restaurantID, Name: in.Name, Price: in.Price, Signature: in.Signature}
+	err := indexTransaction(db, func(tx *gorm.DB) error {
+		if err := tx.Select("id").First(&Restaurant{}, restaurantID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrRestaurantNotFound
+			}
+			return err
+		}
+		if err := tx.Create(&item).Error; err != nil {
+			return err
+		}
+		return reindexRestaurant(tx, restaurantID)
+	})
+	return item, err
+}
+
+// UpdateMenuItem: 메뉴 이름/가격/대표 여부 수정
+func UpdateMenuItem(db *gorm.DB, id uint, in MenuItemInput) (MenuItem, error) {
+	var item MenuItem
+	err := indexTransaction(db, func(tx *gorm.DB) error {
+		if err := tx.First(&item, id).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrMenuItemNotFound
+			}
+			return err
+		}
+		item.Name, item.Price, item.Signature = in.Name, in.Price, in.Signature
+		if err := tx.Select("Name", "Price", "Signature").Updates(&item).Error; err != nil {
+			return err
+		}
+		return reindexRestaurant(tx, item.RestaurantID)
+	})
+	return item, err
+}
+
+// DeleteMenuItem: 메뉴 삭제
+func DeleteMenuItem(db *gorm.DB, id uint) error {
+	return indexTransaction(db, func(tx *gorm.DB) error {
+		var item MenuItem
+		if err := tx.First(&item, id).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrMenuItemNotFound
+			}
+			return err
+		}
+		if err := tx.Delete(&item).Error; err != nil {
+			return err
+		}
+		return reindexRestaurant(tx, item.RestaurantID)
+	})
+}
@@ -0,0 +1,252 @@
+package store
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"gorm.io/gorm"
+)
+
+var ErrDuplicatePlaceID = errors.New("duplicate place id")
+
+var placeIDPattern = regexp.MustCompile(`place\.map\.kakao\.com/(\d+)`)
+
+// placeIDFromURL: 카카오맵 장소 URL에서 장소 ID 추출 (없으면 "")
+func placeIDFromURL(u string) string {
+	if m := placeIDPattern.FindStringSubmatch(u); m != nil {
+		return m[1]
+	}
+	return ""
+}
+
+// placeIDPtr: 빈 장소 ID는 NULL로 저장 (유니크 인덱스에서 NULL은 중복 허용)
+func placeIDPtr(pid string) *string {
+	if pid == "" {
+		return nil
+	}
+	return &pid
+}
+
+// checkPlaceID: 다른 식당(삭제된 식당 포함)이 같은 장소 ID를 쓰고 있으면 ErrDuplicatePlaceID
+func checkPlaceID(tx *gorm.DB, pid *string, exceptID uint) error {
+	if pid == nil {
+		return nil
+	}
+	var count int64
+	if err := tx.Unscoped().Model(&Restaurant{}).Where("place_id = ? AND id <> ?", *pid, exceptID).Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return ErrDuplicatePlaceID
+	}
+	return nil
+}
+
+// migratePlaceIDs: place_id 컬럼이 없던 DB에 컬럼을 추가하고 URL에서 장소 ID를 채움.
+// 여러 식당이 같은 ID를 쓰면 어느 쪽이 맞는지 알 수 없으므로 비워 둔다 (data-quality 명령으로 확인).
+// 하나라도 실패하면 컬럼 추가까지 되돌려 다음 실행 때 다시 시도한다.
+func migratePlaceIDs(db *gorm.DB) {
+	if !db.Migrator().HasTable(&Restaurant{}) || db.Migrator().HasColumn(&Restaurant{}, "PlaceID") {
+		return
+	}
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Migrator().AddColumn(&Restaurant{}, "PlaceID"); err != nil {
+			return fmt.Errorf("place_id 컬럼 추가: %w", err)
+		}
+
+		var list []Restaurant
+		if err := tx.Unscoped().Select("id", "url").Order("id").Find(&list).Error; err != nil {
+			return err
+		}
+		byPlace := map[string][]uint{}
+		for _, res := range list {
+			if pid := placeIDFromURL(res.URL); pid != "" {
+				byPlace[pid] = append(byPlace[pid], res.ID)
+			}
+		}
+		for pid, ids := range byPlace {
+			if len(ids) > 1 {
+				log.Printf("WARN  식당 %v가 장소 ID %s를 함께 사용해 비워 둡니다.\n", ids, pid)
+				continue
+			}
+			if err := tx.Unscoped().Model(&Restaurant{}).Where("id = ?", ids[0]).UpdateColumn("place_id", pid).Error; err != nil {
+				return fmt.Errorf("식당 %d 장소 ID %s: %w", ids[0], pid, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		log.Println("ERROR 장소 ID 채우기 실패:", err)
+	}
+}
+
+// QualityEntry: 데이터 점검 결과에 포함된 식당
+type QualityEntry struct {
+	ID    uint    `json:"id"`
+	Title string  `json:"title"`
+	Addr  string  `json:"addr"`
+	X     float64 `json:"x"`
+	Y     float64 `json:"y"`
+	URL   string  `json:"url"`
+}
+
+// QualityIssue: 같은 문제로 묶인 식당들
+type QualityIssue struct {
+	Key         string         `json:"key"` // 장소 ID, 좌표 또는 정규화한 이름
+	Restaurants []QualityEntry `json:"restaurants"`
+}
+
+// QualityReport: 식당 데이터 점검 결과
+type QualityReport struct {
+	SharedPlaceIDs  []QualityIssue `json:"shared_place_ids"` // 같은 카카오 장소 URL을 쓰는 식당
+	SameCoordinates []QualityIssue `json:"same_coordinates"` // 좌표는 같은데 주소가 다른 식당
+	SimilarTitles   []QualityIssue `json:"similar_titles"`   // 이름이 거의 같은 식당
+}
+
+// Empty: 발견된 문제가 없는지 여부
+func (r QualityReport) Empty() bool {
+	return len(r.SharedPlaceIDs) == 0 && len(r.SameCoordinates) == 0 && len(r.SimilarTitles) == 0
+}
+
+// DataQualityReport: 삭제되지 않은 식당 전체를 점검
+func DataQualityReport(db *gorm.DB) (QualityReport, error) {
+	report := QualityReport{SharedPlaceIDs: []QualityIssue{}, SameCoordinates: []QualityIssue{}, SimilarTitles: []QualityIssue{}}
+
+	var list []Restaurant
+	if err := db.Order("id").Find(&list).Error; err != nil {
+		return report, err
+	}
+	entries := make([]QualityEntry, len(list))
+	for i, res := range list {
+		entries[i] = QualityEntry{ID: res.ID, Title: res.Title, Addr: res.Addr, X: res.X, Y: res.Y, URL: res.URL}
+	}
+
+	// 컬럼은 유니크이므로 URL 기준으로 비교해야 겹친 항목이 드러난다
+	report.SharedPlaceIDs = groupIssues(entries, func(e QualityEntry) string { return placeIDFromURL(e.URL) })
+
+	for _, issue := range groupIssues(entries, func(e QualityEntry) string { return fmt.Sprintf("%f,%f", e.X, e.Y) }) {
+		addrs := map[string]bool{}
+		for _, e := range issue.Restaurants {
+			addrs[normalizeAddr(e.Addr)] = true
+		}
+		if len(addrs) > 1 {
+			report.SameCoordinates = append(report.SameCoordinates, issue)
+		}
+	}
+
+	for i := range entries {
+		for j := i + 1; j < len(entries); j++ {
+			a, b := normalizeTitle(entries[i].Title), normalizeTitle(entries[j].Title)
+			if similarTitles(a, b) {
+				report.SimilarTitles = append(report.SimilarTitles, QualityIssue{
+					Key:         a,
+					Restaurants: []QualityEntry{entries[i], entries[j]},
+				})
+			}
+		}
+	}
+	return report, nil
+}
+
+// groupIssues: key가 같은 식당이 둘 이상인 그룹 (빈 key는 무시)
+func groupIssues(entries []QualityEntry, key func(QualityEntry) string) []QualityIssue {
+	groups := map[string][]QualityEntry{}
+	var keys []string
+	for _, e := range entries {
+		k := key(e)
+		if k == "" {
+			continue
+		}
+		if _, ok := groups[k]; !ok {
+			keys = append(keys, k)
+		}
+		groups[k] = append(groups[k], e)
+	}
+
+	issues := []QualityIssue{}
+	for _, k := range keys {
+		if len(groups[k]) > 1 {
+			issues = append(issues, QualityIssue{Key: k, Restaurants: groups[k]})
+		}
+	}
+	return issues
+}
+
+// 주소 첫 단어의 시/도 접미사 ("경기도" → "경기")
+var regionSuffixes = []string{"특별자치도", "특별자치시", "특별시", "광역시", "도"}
+
+// normalizeAddr: 시/도 표기와 층/호수를 맞춘 주소 ("경기도 … 36 1층" → "경기 … 36")
+func normalizeAddr(addr string) string {
+	fields := strings.Fields(addr)
+	if len(fields) > 0 {
+		for _, suffix := range regionSuffixes {
+			if trimmed := strings.TrimSuffix(fields[0], suffix); trimmed != fields[0] && trimmed != "" {
+				fields[0] = trimmed
+				break
+			}
+		}
+	}
+	for len(fields) > 1 {
+		last := fields[len(fields)-1]
+		if !strings.HasSuffix(last, "층") && !strings.HasSuffix(last, "호") {
+			break
+		}
+		fields = fields[:len(fields)-1]
+	}
+	return strings.Join(fields, " ")
+}
+
+// normalizeTitle: 공백/기호와 "○○점" 같은 지점명을 뺀 소문자 이름
+func normalizeTitle(title string) string {
+	fields := strings.Fields(title)
+	if len(fields) > 1 && strings.HasSuffix(fields[len(fields)-1], "점") {
+		fields = fields[:len(fields)-1]
+	}
+	var b strings.Builder
+	for _, r := range strings.Join(fields, "") {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(unicode.ToLower(r))
+		}
+	}
+	return b.String()
+}
+
+// similarTitles: 정규화한 이름이 같거나, 한쪽이 다른 쪽을 포함하거나, 한 글자만 다른지
+func similarTitles(a, b string) bool {
+	ra, rb := []rune(a), []rune(b)
+	if len(ra) < 2 || len(rb) < 2 {
+		return false
+	}
+	if a == b {
+		return true
+	}
+	if min(len(ra), len(rb)) >= 3 && (strings.Contains(a, b) || strings.Contains(b, a)) {
+		return true
+	}
+	return min(len(ra), len(rb)) >= 4 && editDistance(ra, rb) <= 1
+}
+
+// editDistance: 두 문자열의 레벤슈타인 거리
+func editDistance(a, b []rune) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
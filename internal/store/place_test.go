@@ -0,0 +1,113 @@
+package store
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestDataQualityReport(t *testing.T) {
+	type entry struct {
+		title, addr string
+		x, y        float64
+		url         string
+		deleted     bool
+	}
+	tests := []struct {
+		name                  string
+		restaurants           []entry
+		shared, coords, names []string // 문제별 그룹 key
+	}{
+		{
+			name: "문제 없음",
+			restaurants: []entry{
+				{"가야밀면", "경기 안양시 만안구 성결대학로 1", 126.931, 37.381, "https://place.map.kakao.com/100", false},
+				{"남촌김밥", "경기 안양시 만안구 성결대학로 2", 126.932, 37.382, "https://place.map.kakao.com/200", false},
+			},
+		},
+		{
+			name: "같은 장소 URL",
+			restaurants: []entry{
+				{"더카페", "경기 안양시 만안구 성결대학로 1", 126.931, 37.381, "https://place.map.kakao.com/100", false},
+				{"하이포커스", "경기 안양시 만안구 성결대학로 2", 126.932, 37.382, "https://place.map.kakao.com/100", false},
+			},
+			shared: []string{"100"},
+		},
+		{
+			name: "삭제된 식당은 제외",
+			restaurants: []entry{
+				{"더카페", "경기 안양시 만안구 성결대학로 1", 126.931, 37.381, "https://place.map.kakao.com/100", false},
+				{"하이포커스", "경기 안양시 만안구 성결대학로 2", 126.932, 37.382, "https://place.map.kakao.com/100", true},
+			},
+		},
+		{
+			name: "좌표는 같은데 주소가 다름",
+			restaurants: []entry{
+				{"더카페", "경기 안양시 만안구 성결대학로 1", 126.931, 37.381, "", false},
+				{"하이포커스", "경기 안양시 만안구 성결대학로 9", 126.931, 37.381, "", false},
+			},
+			coords: []string{"126.931000,37.381000"},
+		},
+		{
+			name: "시/도와 층 표기만 다른 주소",
+			restaurants: []entry{
+				{"더카페", "경기도 안양시 만안구 성결대학로 1 2층", 126.931, 37.381, "", false},
+				{"하이포커스", "경기 안양시 만안구 성결대학로 1", 126.931, 37.381, "", false},
+			},
+		},
+		{
+			name: "비슷한 이름",
+			restaurants: []entry{
+				{"엽기떡볶이", "경기 안양시 만안구 성결대학로 1", 126.931, 37.381, "", false},
+				{"동대문 엽기떡볶이", "경기 안양시 만안구 성결대학로 2", 126.932, 37.382, "", false},
+				{"가야밀면 안양점", "경기 안양시 만안구 성결대학로 3", 126.933, 37.383, "", false},
+				{"가야밀면", "경기 안양시 만안구 성결대학로 4", 126.934, 37.384, "", false},
+				{"신전떡볶이", "경기 안양시 만안구 성결대학로 5", 126.935, 37.385, "", false},
+			},
+			names: []string{"엽기떡볶이", "가야밀면"},
+		},
+	}
+	keys := func(issues []QualityIssue) []string {
+		var out []string
+		for _, issue := range issues {
+			if len(issue.Restaurants) < 2 {
+				t.Errorf("issue %q has %d restaurants", issue.Key, len(issue.Restaurants))
+			}
+			out = append(out, issue.Key)
+		}
+		return out
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := newTestDB(t)
+			for _, e := range tt.restaurants {
+				// 장소 ID 컬럼은 유니크라 URL만 겹치게 저장
+				res := Restaurant{Title: e.title, Addr: e.addr, Food: "한식", X: e.x, Y: e.y, URL: e.url}
+				if err := db.Create(&res).Error; err != nil {
+					t.Fatal(err)
+				}
+				if e.deleted {
+					if err := db.Delete(&res).Error; err != nil {
+						t.Fatal(err)
+					}
+				}
+			}
+
+			report, err := DataQualityReport(db)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := keys(report.SharedPlaceIDs); !slices.Equal(got, tt.shared) {
+				t.Errorf("shared place IDs = %v, want %v", got, tt.shared)
+			}
+			if got := keys(report.SameCoordinates); !slices.Equal(got, tt.coords) {
+				t.Errorf("same coordinates = %v, want %v", got, tt.coords)
+			}
+			if got := keys(report.SimilarTitles); !slices.Equal(got, tt.names) {
+				t.Errorf("similar titles = %v, want %v", got, tt.names)
+			}
+			if report.Empty() != (len(tt.shared)+len(tt.coords)+len(tt.names) == 0) {
+				t.Errorf("empty = %v", report.Empty())
+			}
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package store
+
+import (
+	"errors"
+	"math/rand/v2"
+
+	"gorm.io/gorm"
+)
+
+const (
+	priorRating = 3.0 // 가중치 계산 시 별점이 적은 식당에 적용할 기본 별점
+	priorWeight = 2.0 // 기본 별점의 반영 비중 (가상 평가 수)
+)
+
+var ErrNoCandidates = errors.New("no candidates")
+
+// PickRestaurant: 필터를 통과한 식당 중 하나를 무작위로 고름.
+// weighted면 평균 별점/참여 인원이 높을수록 뽑힐 확률이 높다.
+func PickRestaurant(db *gorm.DB, f RestaurantFilter, weighted bool) (*RestaurantResult, error) {
+	var list []Restaurant
+	if err := preloadDetails(f.Geo.apply(f.apply(db.Model(&Restaurant{})))).Find(&list).Error; err != nil {
+		return nil, err
+	}
+	candidates := f.Geo.withDistance(list)
+	if len(candidates) == 0 {
+		return nil, ErrNoCandidates
+	}
+	if !weighted {
+		return &candidates[rand.IntN(len(candidates))], nil
+	}
+
+	weights := make([]float64, len(candidates))
+	var sum float64
+	for i, res := range candidates {
+		weights[i] = ratingWeight(res.AvgRating, res.RatingCount)
+		sum += weights[i]
+	}
+	r := rand.Float64() * sum
+	for i, w := range weights {
+		if r < w {
+			return &candidates[i], nil
+		}
+		r -= w
+	}
+	return &candidates[len(candidates)-1], nil
+}
+
+// ratingWeight: 참여 인원을 반영한 베이지안 평균 별점의 제곱.
+// 평가가 없는 식당도 기본 별점으로 뽑힐 수 있고, 평가가 많을수록 실제 별점에 가까워진다.
+func ratingWeight(avg float64, count int) float64 {
+	score := (avg*float64(count) + priorRating*priorWeight) / (float64(count) + priorWeight)
+	return score * score
+}
@@ -0,0 +1,84 @@
+package store
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestRatingWeight(t *testing.T) {
+	tests := []struct {
+		avg   float64
+		count int
+		want  float64
+	}{
+		{0, 0, 9},        // 평가가 없으면 기본 별점 3점
+		{5, 2, 16},       // (5*2 + 3*2) / 4 = 4
+		{1, 2, 4},        // (1*2 + 3*2) / 4 = 2
+		{5, 98, 24.6016}, // (5*98 + 3*2) / 100 = 4.96
+		{3, 50, 9},       // 기본 별점과 같으면 인원과 무관
+		{4.5, 1, 12.25},  // (4.5 + 6) / 3 = 3.5
+	}
+	for _, tt := range tests {
+		if got := ratingWeight(tt.avg, tt.count); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("ratingWeight(%v, %d) = %v, want %v", tt.avg, tt.count, got, tt.want)
+		}
+	}
+	// 같은 별점이면 평가가 많을수록 가중치가 커진다
+	if ratingWeight(5, 10) <= ratingWeight(5, 1) {
+		t.Error("weight does not grow with rating count")
+	}
+}
+
+func TestPickRestaurant(t *testing.T) {
+	db := newTestDB(t)
+	good := addRestaurant(t, db, 1, "가야밀면", "한식")
+	bad := addRestaurant(t, db, 2, "남촌김밥", "분식")
+	for id, avg := range map[uint]float64{good.ID: 5, bad.ID: 1} {
+		if err := db.Model(&Restaurant{}).Where("id = ?", id).
+			Updates(map[string]any{"avg_rating": avg, "rating_count": 20}).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name   string
+		filter RestaurantFilter
+		want   string // 비어 있으면 ErrNoCandidates
+	}{
+		{"제외", RestaurantFilter{Exclude: []uint{good.ID}}, "남촌김밥"},
+		{"카테고리", RestaurantFilter{Category: "한식"}, "가야밀면"},
+		{"최소 별점", RestaurantFilter{MinRating: 4}, "가야밀면"},
+		{"모두 제외", RestaurantFilter{Exclude: []uint{good.ID, bad.ID}}, ""},
+		{"검색 결과 없음", RestaurantFilter{Search: "짬뽕"}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, weighted := range []bool{false, true} {
+				got, err := PickRestaurant(db, tt.filter, weighted)
+				if tt.want == "" {
+					if !errors.Is(err, ErrNoCandidates) {
+						t.Errorf("weighted=%v: pick = %+v, %v, want ErrNoCandidates", weighted, got, err)
+					}
+					continue
+				}
+				if err != nil || got.Title != tt.want {
+					t.Errorf("weighted=%v: pick = %+v, %v, want %s", weighted, got, err, tt.want)
+				}
+			}
+		})
+	}
+
+	// 가중치 약 23.2 대 1.4 → 별점 높은 식당이 94% 정도
+	picks := map[string]int{}
+	for range 500 {
+		got, err := PickRestaurant(db, RestaurantFilter{}, true)
+		if err != nil {
+			t.Fatal(err)
+		}
+		picks[got.Title]++
+	}
+	if picks["가야밀면"] < 420 || picks["남촌김밥"] == 0 {
+		t.Errorf("weighted picks = %v", picks)
+	}
+}
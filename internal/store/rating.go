@@ -0,0 +1,142 @@
+package store
+
+import (
+	"errors"
+	"log"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// 평균 별점/참여 인원을 ratings 테이블에서 다시 계산하는 SQL
+const recalcRatingSQL = `
+UPDATE restaurants SET
+	avg_rating = COALESCE((SELECT AVG(score) FROM ratings WHERE ratings.restaurant_id = restaurants.id AND ratings.deleted_at IS NULL), 0),
+	rating_count = (SELECT COUNT(*) FROM ratings WHERE ratings.restaurant_id = restaurants.id AND ratings.deleted_at IS NULL)`
+
+var (
+	ErrRestaurantNotFound = errors.New("restaurant not found")
+	ErrRatingNotFound     = errors.New("rating not found")
+)
+
+// RateRestaurant: 별점(및 리뷰) 저장과 평균 재계산을 하나의 트랜잭션으로 처리.
+// requireExisting이면 기존 별점이 있을 때만 수정하고, review가 nil이면 리뷰는 그대로 둔다.
+func RateRestaurant(db *gorm.DB, restaurantID, userID uint, score float64, requireExisting bool, review *ReviewInput) (Restaurant, error) {
+	var res Restaurant
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.First(&res, restaurantID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrRestaurantNotFound
+			}
+			return err
+		}
+		if requireExisting {
+			var existing Rating
+			if err := tx.Where("restaurant_id = ? AND user_id = ?", restaurantID, userID).First(&existing).Error; err != nil {
+				if errors.Is(err, gorm.ErrRecordNotFound) {
+					return ErrRatingNotFound
+				}
+				return err
+			}
+		}
+		rating, err := saveRating(tx, restaurantID, userID, score)
+		if err != nil {
+			return err
+		}
+		if review != nil {
+			if err := saveReview(tx, rating.ID, *review); err != nil {
+				return err
+			}
+		}
+		res, err = recalcRating(tx, restaurantID)
+		return err
+	})
+	return res, err
+}
+
+// RetractRating: 별점 철회와 평균 재계산을 하나의 트랜잭션으로 처리
+func RetractRating(db *gorm.DB, restaurantID, userID uint) (Restaurant, error) {
+	var res Restaurant
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := deleteRating(tx, restaurantID, userID); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrRatingNotFound
+			}
+			return err
+		}
+		var err error
+		res, err = recalcRating(tx, restaurantID)
+		return err
+	})
+	return res, err
+}
+
+// saveRating: 사용자 별점을 저장 (이미 있으면 점수 갱신)
+func saveRating(db *gorm.DB, restaurantID, userID uint, score float64) (Rating, error) {
+	rating := Rating{RestaurantID: restaurantID, UserID: userID, Score: score}
+	if err := db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "restaurant_id"}, {Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"score", "updated_at"}),
+	}).Create(&rating).Error; err != nil {
+		return rating, err
+	}
+	// ON CONFLICT 갱신 시에는 ID가 채워지지 않으므로 다시 조회
+	err := db.Where("restaurant_id = ? AND user_id = ?", restaurantID, userID).First(&rating).Error
+	return rating, err
+}
+
+// deleteRating: 사용자 별점(및 리뷰) 철회. 삭제된 행이 없으면 gorm.ErrRecordNotFound
+func deleteRating(db *gorm.DB, restaurantID, userID uint) error {
+	if err := db.Unscoped().
+		Where("rating_id IN (SELECT id FROM ratings WHERE restaurant_id = ? AND user_id = ?)", restaurantID, userID).
+		Delete(&Review{}).Error; err != nil {
+		return err
+	}
+	result := db.Unscoped().
+		Where("restaurant_id = ? AND user_id = ?", restaurantID, userID).
+		Delete(&Rating{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// recalcRating: 식당 하나의 평균 별점/참여 인원을 다시 계산
+func recalcRating(db *gorm.DB, restaurantID uint) (Restaurant, error) {
+	var res Restaurant
+	if err := db.Exec(recalcRatingSQL+" WHERE id = ?", restaurantID).Error; err != nil {
+		return res, err
+	}
+	err := db.First(&res, restaurantID).Error
+	return res, err
+}
+
+// RecalcAllRatings: 전체 식당의 평균 별점/참여 인원을 다시 계산
+func RecalcAllRatings(db *gorm.DB) (int64, error) {
+	result := db.Exec(recalcRatingSQL + " WHERE deleted_at IS NULL")
+	return result.RowsAffected, result.Error
+}
+
+// dedupeRatings: 같은 사용자가 같은 식당에 남긴 중복 별점 중 최신 1건만 남김
+func dedupeRatings(db *gorm.DB) {
+	if !db.Migrator().HasTable(&Rating{}) {
+		return
+	}
+	result := db.Exec(`
+DELETE FROM ratings WHERE deleted_at IS NOT NULL OR (user_id IS NOT NULL AND id NOT IN (
+	SELECT MAX(id) FROM ratings WHERE deleted_at IS NULL GROUP BY restaurant_id, user_id
+))`)
+	if result.Error != nil {
+		log.Println("ERROR 중복 별점 정리 실패:", result.Error)
+		return
+	}
+	if result.RowsAffected > 0 {
+		log.Printf("INFO  중복 별점 %d건 정리\n", result.RowsAffected)
+		if _, err := RecalcAllRatings(db); err != nil {
+			log.Println("ERROR 별점 재계산 실패:", err)
+		}
+	}
+}
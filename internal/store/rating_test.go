@@ -0,0 +1,63 @@
+package store
+
+import (
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+// 여러 사용자가 동시에 평가해도 평균 별점/참여 인원이 빠짐없이 집계되어야 한다
+func TestRateRestaurantConcurrent(t *testing.T) {
+	// 메모리 DB는 연결이 하나뿐이라 실제 동시 실행을 보려면 파일 DB가 필요
+	db, err := Open(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if sqlDB, err := db.DB(); err == nil {
+			sqlDB.Close()
+		}
+	})
+	res := addRestaurant(t, db, 1, "가야밀면", "한식")
+
+	const users = 20
+	ids := make([]uint, users)
+	for i := range ids {
+		ids[i] = addUser(t, db, int64(1001+i)).ID
+	}
+
+	tests := []struct {
+		name  string
+		score func(i int) float64
+		avg   float64
+	}{
+		{"첫 평가", func(i int) float64 { return float64(i%5 + 1) }, 3},           // 1~5점 4번씩
+		{"다시 평가", func(i int) float64 { return float64(i%2)*1.5 + 3.5 }, 4.25}, // 3.5점, 5점 10번씩
+	}
+	for _, tt := range tests {
+		var wg sync.WaitGroup
+		errs := make(chan error, users)
+		for i, id := range ids {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if _, err := RateRestaurant(db, res.ID, id, tt.score(i), false, nil); err != nil {
+					errs <- err
+				}
+			}()
+		}
+		wg.Wait()
+		close(errs)
+		for err := range errs {
+			t.Errorf("%s: %v", tt.name, err)
+		}
+
+		var got Restaurant
+		if err := db.First(&got, res.ID).Error; err != nil {
+			t.Fatal(err)
+		}
+		if got.RatingCount != users || got.AvgRating != tt.avg {
+			t.Errorf("%s: rating = %v (%d), want %v (%d)", tt.name, got.AvgRating, got.RatingCount, tt.avg, users)
+		}
+	}
+}
@@ -0,0 +1,197 @@
+package store
+
+import (
+	"errors"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"gorm.io/gorm"
+)
+
+const (
+	MaxReviewLength = 1000 // 리뷰 본문 최대 글자 수
+	maxReviewTags   = 10   // 장점/단점 태그 최대 개수
+)
+
+var (
+	ErrReviewNotFound = errors.New("review not found")
+	ErrReviewTooLong  = errors.New("review too long")
+	ErrReviewEmpty    = errors.New("review empty")
+)
+
+// ReviewInput: 리뷰 작성/수정 요청 값
+type ReviewInput struct {
+	Body string
+	Pros []string
+	Cons []string
+}
+
+// empty: 본문과 태그가 모두 비어 있는지 여부
+func (in ReviewInput) empty() bool {
+	return strings.TrimSpace(in.Body) == "" && len(in.Pros) == 0 && len(in.Cons) == 0
+}
+
+// normalize: 공백/중복 태그 정리 및 길이 검사
+func (in ReviewInput) normalize() (ReviewInput, error) {
+	in.Body = strings.TrimSpace(in.Body)
+	if utf8.RuneCountInString(in.Body) > MaxReviewLength {
+		return in, ErrReviewTooLong
+	}
+	in.Pros = normalizeTags(in.Pros)
+	in.Cons = normalizeTags(in.Cons)
+	return in, nil
+}
+
+func normalizeTags(tags []string) []string {
+	seen := map[string]bool{}
+	out := []string{}
+	for _, tag := range tags {
+		for _, t := range strings.Split(tag, ",") {
+			t = strings.TrimSpace(t)
+			if t == "" || seen[t] {
+				continue
+			}
+			seen[t] = true
+			out = append(out, t)
+			if len(out) == maxReviewTags {
+				return out
+			}
+		}
+	}
+	return out
+}
+
+// ReviewView: 리뷰 목록 응답 항목 (별점/작성자 포함)
+type ReviewView struct {
+	ID        uint       `json:"id"`
+	UserID    uint       `json:"user_id"`
+	Nickname  string     `json:"nickname"`
+	Score     float64    `json:"score"`
+	Body      string     `json:"body"`
+	Pros      []string   `json:"pros" gorm:"serializer:json"`
+	Cons      []string   `json:"cons" gorm:"serializer:json"`
+	CreatedAt time.Time  `json:"created_at"`
+	EditedAt  *time.Time `json:"edited_at"`
+}
+
+// 리뷰 목록 정렬 기준
+var reviewSorts = map[string]string{
+	"newest":  "reviews.created_at DESC, reviews.id DESC",
+	"highest": "ratings.score DESC, reviews.created_at DESC",
+	"lowest":  "ratings.score ASC, reviews.created_at DESC",
+}
+
+// ListReviews: 식당 리뷰 목록과 전체 개수 조회
+func ListReviews(db *gorm.DB, restaurantID uint, sort string, limit, offset int) ([]ReviewView, int64, error) {
+	order, ok := reviewSorts[sort]
+	if !ok {
+		order = reviewSorts["newest"]
+	}
+
+	query := db.Table("reviews").
+		Joins("JOIN ratings ON ratings.id = reviews.rating_id").
+		Joins("LEFT JOIN users ON users.id = ratings.user_id").
+		Where("ratings.restaurant_id = ? AND reviews.deleted_at IS NULL AND ratings.deleted_at IS NULL", restaurantID)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	list := []ReviewView{}
+	err := query.
+		Select("reviews.id, ratings.user_id, users.nickname, ratings.score, reviews.body, reviews.pros, reviews.cons, reviews.created_at, reviews.edited_at").
+		Order(order).
+		Limit(limit).
+		Offset(offset).
+		Scan(&list).Error
+	return list, total, err
+}
+
+// saveReview: 별점에 딸린 리뷰를 작성/수정. 내용이 비어 있으면 리뷰를 삭제
+func saveReview(tx *gorm.DB, ratingID uint, in ReviewInput) error {
+	in, err := in.normalize()
+	if err != nil {
+		return err
+	}
+	if in.empty() {
+		return tx.Unscoped().Where("rating_id = ?", ratingID).Delete(&Review{}).Error
+	}
+
+	var review Review
+	err = tx.Where("rating_id = ?", ratingID).First(&review).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		review = Review{RatingID: ratingID, Body: in.Body, Pros: in.Pros, Cons: in.Cons}
+		return tx.Create(&review).Error
+	}
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	review.Body, review.Pros, review.Cons, review.EditedAt = in.Body, in.Pros, in.Cons, &now
+	return tx.Save(&review).Error
+}
+
+// findOwnReview: 로그인 사용자가 작성한 리뷰 조회
+func findOwnReview(db *gorm.DB, reviewID, userID uint) (Review, error) {
+	var review Review
+	err := db.Joins("JOIN ratings ON ratings.id = reviews.rating_id").
+		Where("reviews.id = ? AND ratings.user_id = ?", reviewID, userID).
+		First(&review).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return review, ErrReviewNotFound
+	}
+	return review, err
+}
+
+// ReviewPatch: 리뷰 수정 요청 값 (nil인 필드는 기존 값 유지, 빈 목록은 태그 삭제)
+type ReviewPatch struct {
+	Body *string
+	Pros []string
+	Cons []string
+}
+
+// EditReview: 본인 리뷰 수정. 보내지 않은 필드는 그대로 두고, 결과가 비면 ErrReviewEmpty
+func EditReview(db *gorm.DB, reviewID, userID uint, patch ReviewPatch) (Review, error) {
+	var review Review
+	err := db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		if review, err = findOwnReview(tx, reviewID, userID); err != nil {
+			return err
+		}
+		in := ReviewInput{Body: review.Body, Pros: review.Pros, Cons: review.Cons}
+		if patch.Body != nil {
+			in.Body = *patch.Body
+		}
+		if patch.Pros != nil {
+			in.Pros = patch.Pros
+		}
+		if patch.Cons != nil {
+			in.Cons = patch.Cons
+		}
+		if in, err = in.normalize(); err != nil {
+			return err
+		}
+		if in.empty() {
+			return ErrReviewEmpty
+		}
+		if err := saveReview(tx, review.RatingID, in); err != nil {
+			return err
+		}
+		return tx.Where("rating_id = ?", review.RatingID).Limit(1).Find(&review).Error
+	})
+	return review, err
+}
+
+// DeleteReview: 본인 리뷰 삭제 (별점은 유지)
+func DeleteReview(db *gorm.DB, reviewID, userID uint) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		review, err := findOwnReview(tx, reviewID, userID)
+		if err != nil {
+			return err
+		}
+		return tx.Unscoped().Delete(&review).Error
+	})
+}
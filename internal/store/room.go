@@ -0,0 +1,349 @@
+package store
+
+import (
+	"crypto/rand"
+	"errors"
+	"math/big"
+	mrand "math/rand/v2"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	roomOpen   = "open"
+	roomClosed = "closed"
+
+	roomCodeLength    = 6
+	roomCodeAlphabet  = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789" // 헷갈리는 글자(0/O, 1/I) 제외
+	MaxRoomCandidates = 20
+)
+
+var (
+	ErrRoomNotFound       = errors.New("room not found")
+	ErrRoomClosed         = errors.New("room closed")
+	ErrNotRoomMember      = errors.New("not a room member")
+	ErrNotRoomOwner       = errors.New("not the room owner")
+	ErrCandidateNotFound  = errors.New("candidate not found")
+	ErrTooManyCandidates  = errors.New("too many candidates")
+	ErrRoomNoCandidates   = errors.New("room has no candidates")
+	ErrDuplicateCandidate = errors.New("duplicate candidate")
+)
+
+// 같이 먹을 곳을 정하는 방 (코드를 공유해 참여)
+type Room struct {
+	ID        uint       `json:"-" gorm:"primarykey"`
+	Code      string     `json:"code" gorm:"uniqueIndex;size:8"`
+	Title     string     `json:"title"`
+	OwnerID   uint       `json:"owner_id"`
+	Status    string     `json:"status" gorm:"default:open"` // open 또는 closed
+	WinnerID  *uint      `json:"-"`                          // 결정된 후보 (room_candidates.id)
+	ClosedAt  *time.Time `json:"closed_at"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"-"`
+}
+
+// 방 참여자
+type RoomMember struct {
+	RoomID    uint `gorm:"primaryKey"`
+	UserID    uint `gorm:"primaryKey"`
+	User      User
+	CreatedAt time.Time
+}
+
+// 방에 제안된 식당
+type RoomCandidate struct {
+	ID           uint `gorm:"primarykey"`
+	RoomID       uint `gorm:"uniqueIndex:idx_room_candidate"`
+	RestaurantID uint `gorm:"uniqueIndex:idx_room_candidate"`
+	ProposedBy   uint
+	CreatedAt    time.Time
+}
+
+// 투표 (방당 사용자 1표, 다시 투표하면 바뀜)
+type RoomVote struct {
+	RoomID      uint `gorm:"primaryKey"`
+	UserID      uint `gorm:"primaryKey"`
+	CandidateID uint `gorm:"index"`
+	UpdatedAt   time.Time
+}
+
+// RoomState: 방 조회/실시간 알림 응답
+type RoomState struct {
+	Room
+	Members    []RoomMemberView    `json:"members"`
+	Candidates []RoomCandidateView `json:"candidates"`
+	MyVote     *uint               `json:"my_vote"` // 내가 투표한 후보 ID
+	Winner     *RoomCandidateView  `json:"winner"`  // 마감 후 결정된 식당
+}
+
+// RoomMemberView: 참여자 응답 항목
+type RoomMemberView struct {
+	UserID   uint   `json:"user_id"`
+	Nickname string `json:"nickname"`
+}
+
+// RoomCandidateView: 후보 응답 항목 (득표 수 포함)
+type RoomCandidateView struct {
+	ID         uint       `json:"id"`
+	Restaurant Restaurant `json:"restaurant"`
+	ProposedBy uint       `json:"proposed_by"`
+	Votes      int        `json:"votes"`
+}
+
+// newRoomCode: 방 참여 코드
+func newRoomCode() (string, error) {
+	b := make([]byte, roomCodeLength)
+	for i := range b {
+		n, err := rand.Int(rand.Reader, big.NewInt(int64(len(roomCodeAlphabet))))
+		if err != nil {
+			return "", err
+		}
+		b[i] = roomCodeAlphabet[n.Int64()]
+	}
+	return string(b), nil
+}
+
+// normalizeRoomCode: 입력한 코드를 대문자로 맞춤
+func normalizeRoomCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// CreateRoom: 방을 만들고 만든 사람을 참여자로 등록
+func CreateRoom(db *gorm.DB, ownerID uint, title string) (Room, error) {
+	title = strings.TrimSpace(title)
+	if title == "" {
+		title = "오늘 뭐 먹지?"
+	}
+	if utf8.RuneCountInString(title) > 30 {
+		title = string([]rune(title)[:30])
+	}
+
+	var room Room
+	err := db.Transaction(func(tx *gorm.DB) error {
+		// 코드가 겹치면 몇 번 다시 시도
+		for range 5 {
+			code, err := newRoomCode()
+			if err != nil {
+				return err
+			}
+			var count int64
+			if err := tx.Model(&Room{}).Where("code = ?", code).Count(&count).Error; err != nil {
+				return err
+			}
+			if count == 0 {
+				room = Room{Code: code, Title: title, OwnerID: ownerID, Status: roomOpen}
+				break
+			}
+		}
+		if room.Code == "" {
+			return errors.New("room code collision")
+		}
+		if err := tx.Create(&room).Error; err != nil {
+			return err
+		}
+		return tx.Create(&RoomMember{RoomID: room.ID, UserID: ownerID}).Error
+	})
+	return room, err
+}
+
+// findRoom: 코드로 방 조회
+func findRoom(db *gorm.DB, code string) (Room, error) {
+	var room Room
+	err := db.Where("code = ?", normalizeRoomCode(code)).First(&room).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return room, ErrRoomNotFound
+	}
+	return room, err
+}
+
+// FindMemberRoom: 참여한 방만 조회
+func FindMemberRoom(db *gorm.DB, code string, userID uint) (Room, error) {
+	room, err := findRoom(db, code)
+	if err != nil {
+		return room, err
+	}
+	var count int64
+	if err := db.Model(&RoomMember{}).Where("room_id = ? AND user_id = ?", room.ID, userID).Count(&count).Error; err != nil {
+		return room, err
+	}
+	if count == 0 {
+		return room, ErrNotRoomMember
+	}
+	return room, nil
+}
+
+// JoinRoom: 코드로 방에 참여 (이미 참여했으면 그대로)
+func JoinRoom(db *gorm.DB, code string, userID uint) (Room, error) {
+	room, err := findRoom(db, code)
+	if err != nil {
+		return room, err
+	}
+	if room.Status != roomOpen {
+		return room, ErrRoomClosed
+	}
+	err = db.Clauses(clause.OnConflict{DoNothing: true}).Create(&RoomMember{RoomID: room.ID, UserID: userID}).Error
+	return room, err
+}
+
+// ProposeCandidate: 참여자가 식당을 후보로 제안
+func ProposeCandidate(db *gorm.DB, code string, userID, restaurantID uint) (Room, error) {
+	var room Room
+	err := db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		if room, err = FindMemberRoom(tx, code, userID); err != nil {
+			return err
+		}
+		if room.Status != roomOpen {
+			return ErrRoomClosed
+		}
+		if err := findRestaurant(tx, restaurantID); err != nil {
+			return err
+		}
+
+		var existing []uint
+		if err := tx.Model(&RoomCandidate{}).Where("room_id = ?", room.ID).Pluck("restaurant_id", &existing).Error; err != nil {
+			return err
+		}
+		for _, id := range existing {
+			if id == restaurantID {
+				return ErrDuplicateCandidate
+			}
+		}
+		if len(existing) >= MaxRoomCandidates {
+			return ErrTooManyCandidates
+		}
+		return tx.Create(&RoomCandidate{RoomID: room.ID, RestaurantID: restaurantID, ProposedBy: userID}).Error
+	})
+	return room, err
+}
+
+// VoteRoom: 후보에 투표 (이미 투표했으면 바꿈)
+func VoteRoom(db *gorm.DB, code string, userID, candidateID uint) (Room, error) {
+	var room Room
+	err := db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		if room, err = FindMemberRoom(tx, code, userID); err != nil {
+			return err
+		}
+		if room.Status != roomOpen {
+			return ErrRoomClosed
+		}
+		var count int64
+		if err := tx.Model(&RoomCandidate{}).Where("id = ? AND room_id = ?", candidateID, room.ID).Count(&count).Error; err != nil {
+			return err
+		}
+		if count == 0 {
+			return ErrCandidateNotFound
+		}
+		return tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "room_id"}, {Name: "user_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"candidate_id", "updated_at"}),
+		}).Create(&RoomVote{RoomID: room.ID, UserID: userID, CandidateID: candidateID}).Error
+	})
+	return room, err
+}
+
+// CloseRoom: 방장이 투표를 마감하고 최다 득표 후보를 결과로 저장 (동점이면 무작위)
+func CloseRoom(db *gorm.DB, code string, userID uint) (Room, error) {
+	var room Room
+	err := db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		if room, err = findRoom(tx, code); err != nil {
+			return err
+		}
+		if room.OwnerID != userID {
+			return ErrNotRoomOwner
+		}
+		if room.Status != roomOpen {
+			return ErrRoomClosed
+		}
+
+		var tally []struct {
+			ID    uint
+			Votes int
+		}
+		if err := tx.Model(&RoomCandidate{}).
+			Select("room_candidates.id, COUNT(room_votes.user_id) AS votes").
+			Joins("LEFT JOIN room_votes ON room_votes.candidate_id = room_candidates.id AND room_votes.room_id = room_candidates.room_id").
+			Where("room_candidates.room_id = ?", room.ID).
+			Group("room_candidates.id").
+			Scan(&tally).Error; err != nil {
+			return err
+		}
+		if len(tally) == 0 {
+			return ErrRoomNoCandidates
+		}
+
+		best := -1
+		var top []uint
+		for _, t := range tally {
+			switch {
+			case t.Votes > best:
+				best, top = t.Votes, []uint{t.ID}
+			case t.Votes == best:
+				top = append(top, t.ID)
+			}
+		}
+		winner := top[mrand.IntN(len(top))]
+		now := time.Now()
+		room.Status, room.WinnerID, room.ClosedAt = roomClosed, &winner, &now
+		return tx.Select("Status", "WinnerID", "ClosedAt").Updates(&room).Error
+	})
+	return room, err
+}
+
+// LoadRoomState: 참여자, 후보(득표 수), 내 투표, 결과를 모은 방 상태
+func LoadRoomState(db *gorm.DB, room Room, userID uint) (RoomState, error) {
+	state := RoomState{Room: room, Members: []RoomMemberView{}, Candidates: []RoomCandidateView{}}
+
+	var members []RoomMember
+	if err := db.Preload("User").Where("room_id = ?", room.ID).Order("created_at").Find(&members).Error; err != nil {
+		return state, err
+	}
+	for _, m := range members {
+		state.Members = append(state.Members, RoomMemberView{UserID: m.UserID, Nickname: m.User.Nickname})
+	}
+
+	var votes []RoomVote
+	if err := db.Where("room_id = ?", room.ID).Find(&votes).Error; err != nil {
+		return state, err
+	}
+	counts := map[uint]int{}
+	for _, v := range votes {
+		counts[v.CandidateID]++
+		if v.UserID == userID {
+			state.MyVote = &v.CandidateID
+		}
+	}
+
+	var candidates []RoomCandidate
+	if err := db.Where("room_id = ?", room.ID).Order("id").Find(&candidates).Error; err != nil {
+		return state, err
+	}
+	ids := make([]uint, len(candidates))
+	for i, cand := range candidates {
+		ids[i] = cand.RestaurantID
+	}
+	// 투표 중에 삭제된 식당도 결과에는 보여야 하므로 Unscoped
+	list, err := restaurantsInOrder(db.Unscoped(), ids)
+	if err != nil {
+		return state, err
+	}
+	byID := make(map[uint]Restaurant, len(list))
+	for _, res := range list {
+		byID[res.ID] = res
+	}
+
+	for _, cand := range candidates {
+		view := RoomCandidateView{ID: cand.ID, Restaurant: byID[cand.RestaurantID], ProposedBy: cand.ProposedBy, Votes: counts[cand.ID]}
+		state.Candidates = append(state.Candidates, view)
+		if room.WinnerID != nil && *room.WinnerID == cand.ID {
+			state.Winner = &view
+		}
+	}
+	return state, nil
+}
@@ -0,0 +1,100 @@
+package store
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCloseRoom(t *testing.T) {
+	db := newTestDB(t)
+	users := []User{addUser(t, db, 1001), addUser(t, db, 1002), addUser(t, db, 1003)}
+	owner := users[0]
+	restaurants := []Restaurant{addRestaurant(t, db, 1, "가야밀면", "한식"), addRestaurant(t, db, 2, "남촌김밥", "분식")}
+
+	tests := []struct {
+		name       string
+		candidates int   // 제안할 후보 수 (restaurants 앞에서부터)
+		votes      []int // 사용자별로 투표할 후보 번호 (-1이면 투표 안 함)
+		closer     int   // 마감하는 사용자 번호
+		err        error
+		winners    []int // 결과가 될 수 있는 후보 번호
+	}{
+		{name: "후보 없음", closer: 0, err: ErrRoomNoCandidates},
+		{name: "방장이 아님", candidates: 2, votes: []int{0, 0, 1}, closer: 1, err: ErrNotRoomOwner},
+		{name: "최다 득표", candidates: 2, votes: []int{1, 1, 0}, winners: []int{1}},
+		{name: "투표 없는 후보 하나", candidates: 1, winners: []int{0}},
+		{name: "동점", candidates: 2, votes: []int{0, 1, -1}, winners: []int{0, 1}},
+		{name: "투표 없음", candidates: 2, winners: []int{0, 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// 동점은 무작위로 정해지므로 여러 번 마감해 후보마다 한 번 이상 뽑히는지 본다
+			runs := 1
+			if len(tt.winners) > 1 {
+				runs = 40
+			}
+			seen := map[int]bool{}
+			for range runs {
+				room, err := CreateRoom(db, owner.ID, tt.name)
+				if err != nil {
+					t.Fatal(err)
+				}
+				for _, u := range users[1:] {
+					if _, err := JoinRoom(db, room.Code, u.ID); err != nil {
+						t.Fatal(err)
+					}
+				}
+				var candidates []RoomCandidate
+				for _, res := range restaurants[:tt.candidates] {
+					if _, err := ProposeCandidate(db, room.Code, owner.ID, res.ID); err != nil {
+						t.Fatal(err)
+					}
+				}
+				if err := db.Where("room_id = ?", room.ID).Order("id").Find(&candidates).Error; err != nil {
+					t.Fatal(err)
+				}
+				for i, v := range tt.votes {
+					if v < 0 {
+						continue
+					}
+					if _, err := VoteRoom(db, room.Code, users[i].ID, candidates[v].ID); err != nil {
+						t.Fatal(err)
+					}
+				}
+
+				closed, err := CloseRoom(db, room.Code, users[tt.closer].ID)
+				if tt.err != nil {
+					if !errors.Is(err, tt.err) {
+						t.Fatalf("close = %v, want %v", err, tt.err)
+					}
+					if err := db.First(&room, room.ID).Error; err != nil || room.Status != roomOpen || room.WinnerID != nil {
+						t.Fatalf("room after failed close = %+v, %v", room, err)
+					}
+					return
+				}
+				if err != nil {
+					t.Fatal(err)
+				}
+				if closed.Status != roomClosed || closed.ClosedAt == nil || closed.WinnerID == nil {
+					t.Fatalf("closed room = %+v", closed)
+				}
+				for i, cand := range candidates {
+					if cand.ID == *closed.WinnerID {
+						seen[i] = true
+					}
+				}
+				if _, err := CloseRoom(db, room.Code, owner.ID); !errors.Is(err, ErrRoomClosed) {
+					t.Fatalf("close twice = %v", err)
+				}
+			}
+			if len(seen) != len(tt.winners) {
+				t.Errorf("winners = %v, want %v", seen, tt.winners)
+			}
+			for _, i := range tt.winners {
+				if !seen[i] {
+					t.Errorf("candidate %d never won, want one of %v", i, tt.winners)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,481 @@
+package store
+
+import (
+	"html"
+	"log"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"gorm.io/gorm"
+)
+
+// 검색 색인 (FTS5). 한글은 띄어쓰기 단위로는 부분 검색이 안 되므로 두 글자씩 겹쳐 자른(bigram) 값을 넣는다.
+// rowid는 restaurants.id
+const createSearchTable = `CREATE VIRTUAL TABLE IF NOT EXISTS restaurant_search USING fts5(title, food, addr, menu, tokenize='unicode61')`
+
+// 필드별 가중치 (이름 > 음식 종류 > 메뉴 > 주소)
+const searchRank = `bm25(restaurant_search, 10.0, 5.0, 1.0, 3.0)`
+
+const snippetRadius = 15 // 스니펫에서 일치 부분 앞뒤로 보여 줄 글자 수
+
+// searchWords: 검색어를 글자/숫자 단위 단어로 나눔 (소문자, 맞춤법 변형은 표준 표기로)
+func searchWords(s string) []string {
+	return splitWords(normalizeSpelling(strings.ToLower(s)))
+}
+
+// splitWords: 글자/숫자가 아닌 문자로 나눈 단어들
+func splitWords(s string) []string {
+	return strings.FieldsFunc(s, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// bigrams: 단어를 두 글자씩 겹쳐 자름 ("가야밀면" → "가야 야밀 밀면"). 한 글자 단어는 그대로
+func bigrams(word string) []string {
+	runes := []rune(word)
+	if len(runes) < 2 {
+		return []string{word}
+	}
+	grams := make([]string, 0, len(runes)-1)
+	for i := 0; i+1 < len(runes); i++ {
+		grams = append(grams, string(runes[i:i+2]))
+	}
+	return grams
+}
+
+// searchTokens: 색인에 넣을 텍스트. 맞춤법 변형이 있는 단어는 원래 표기와 표준 표기를 모두 넣어
+// "돈까스"를 입력 중인 "돈까"로도, 표준 표기로 바뀐 검색어 "돈가스"로도 찾을 수 있게 한다
+func searchTokens(text string) string {
+	var tokens []string
+	for _, word := range splitWords(strings.ToLower(text)) {
+		tokens = append(tokens, bigrams(word)...)
+		if norm := normalizeSpelling(word); norm != word {
+			tokens = append(tokens, bigrams(norm)...)
+		}
+	}
+	return strings.Join(tokens, " ")
+}
+
+// searchMatch: 검색어를 FTS5 MATCH 식으로 변환. 단어마다 bigram을 이어 붙인 구문이 모두 있어야 한다.
+// 한 글자 단어는 그 글자로 시작하는 토큰을 찾는다. 검색할 단어가 없으면 ""
+func searchMatch(search string) string {
+	var phrases []string
+	for _, word := range searchWords(search) {
+		if len([]rune(word)) == 1 {
+			phrases = append(phrases, `"`+word+`"*`)
+			continue
+		}
+		phrases = append(phrases, `"`+strings.Join(bigrams(word), " ")+`"`)
+	}
+	return strings.Join(phrases, " AND ")
+}
+
+// searchHit: 검색어와 맞는 식당과 관련도 (작을수록 관련도 높음)
+type searchHit struct {
+	ID   uint
+	Rank float64
+}
+
+// searchHits: 색인에서 먼저 찾고, 초성 검색어이거나 색인에 결과가 없으면 초성/오타 허용 검색으로 찾음
+func searchHits(db *gorm.DB, search string) ([]searchHit, error) {
+	if !isChosungQuery(search) {
+		var hits []searchHit
+		err := db.Raw("SELECT rowid AS id, "+searchRank+" AS rank FROM restaurant_search WHERE restaurant_search MATCH ?", searchMatch(search)).
+			Scan(&hits).Error
+		if err != nil || len(hits) > 0 {
+			return hits, err
+		}
+	}
+	return fuzzyHits(db, search)
+}
+
+// applySearch: 검색어와 맞는 식당만 남기고 search_rank 컬럼으로 관련도 순 정렬을 할 수 있게 함
+func applySearch(query *gorm.DB, search string) *gorm.DB {
+	if len(searchWords(search)) == 0 {
+		return query
+	}
+	hits, err := searchHits(query.Session(&gorm.Session{NewDB: true}), search)
+	if err != nil {
+		query.AddError(err)
+		return query
+	}
+	if len(hits) == 0 {
+		return query.Joins("JOIN (SELECT 0 AS search_id, 0 AS search_rank) AS search ON search.search_id = restaurants.id")
+	}
+
+	rows := make([]string, len(hits))
+	args := make([]any, 0, len(hits)*2)
+	for i, hit := range hits {
+		rows[i] = "(?, ?)"
+		args = append(args, hit.ID, hit.Rank)
+	}
+	return query.Joins("JOIN (SELECT column1 AS search_id, column2 AS search_rank FROM (VALUES "+strings.Join(rows, ", ")+")) AS search ON search.search_id = restaurants.id", args...)
+}
+
+// searchField: 초성/오타 허용 검색 대상 필드 (weight가 작을수록 중요한 필드)
+type searchField struct {
+	text   []rune
+	weight float64
+}
+
+// compactRunes: 비교용으로 소문자/표준 표기로 바꾸고 공백을 뺀 글자들
+func compactRunes(s string) []rune {
+	return []rune(strings.Join(searchWords(s), ""))
+}
+
+// compactForms: 비교 대상 텍스트의 표준 표기와 (다르면) 원래 표기. 색인과 같이 두 표기 모두로 찾는다
+func compactForms(s string) [][]rune {
+	forms := [][]rune{compactRunes(s)}
+	if raw := []rune(strings.Join(splitWords(strings.ToLower(s)), "")); string(raw) != string(forms[0]) {
+		forms = append(forms, raw)
+	}
+	return forms
+}
+
+// fuzzyScore: 검색어 단어가 필드에 얼마나 잘 맞는지 (맞지 않으면 false).
+// 그대로 포함 < 초성/입력 중 글자로 포함 < 오타 순으로 점수가 커진다.
+func fuzzyScore(fields []searchField, word []rune) (float64, bool) {
+	best, found := 0.0, false
+	for _, f := range fields {
+		var score float64
+		switch idx := hangulIndex(f.text, word); {
+		case strings.Contains(string(f.text), string(word)):
+			score = f.weight
+		case idx >= 0:
+			score = f.weight + 0.5
+		case isChosungQuery(string(word)):
+			continue
+		default:
+			d := typoDistance(f.text, word)
+			if d > maxTypos(word) {
+				continue
+			}
+			score = f.weight + 2*float64(d)
+		}
+		if !found || score < best {
+			best, found = score, true
+		}
+	}
+	return best, found
+}
+
+// appendFields: 텍스트의 표기(compactForms)마다 같은 가중치의 비교 필드를 추가
+func appendFields(fields []searchField, forms [][]rune, weight float64) []searchField {
+	for _, text := range forms {
+		fields = append(fields, searchField{text, weight})
+	}
+	return fields
+}
+
+// fuzzyHits: 이름, 음식 종류, 메뉴를 초성("ㄱㅂ" → 김밥)과 작은 오타("가야밀묜" → 가야밀면)까지 허용해 비교
+func fuzzyHits(db *gorm.DB, search string) ([]searchHit, error) {
+	cands, err := loadSearchCandidates(db)
+	if err != nil {
+		return nil, err
+	}
+	menuFields := map[uint][]searchField{}
+	for _, m := range cands.menus {
+		menuFields[m.RestaurantID] = appendFields(menuFields[m.RestaurantID], m.name, 1)
+	}
+
+	words := searchWords(search)
+	hits := []searchHit{}
+	for _, res := range cands.restaurants {
+		fields := append(appendFields(appendFields(nil, res.title, 0), res.food, 0.5), menuFields[res.ID]...)
+		total, ok := 0.0, true
+		for _, w := range words {
+			score, found := fuzzyScore(fields, []rune(w))
+			if !found {
+				ok = false
+				break
+			}
+			total += score
+		}
+		if ok {
+			hits = append(hits, searchHit{ID: res.ID, Rank: total})
+		}
+	}
+	return hits, nil
+}
+
+// Suggestion: 자동완성 항목
+type Suggestion struct {
+	Type         string  `json:"type"` // restaurant, category, menu
+	Text         string  `json:"text"`
+	RestaurantID uint    `json:"restaurant_id,omitempty"` // restaurant, menu일 때 해당 식당
+	Highlight    string  `json:"highlight"`               // 일치 부분을 <mark>로 감싼 text
+	score        float64 // 작을수록 위
+}
+
+// suggestScore: 자동완성 점수 (앞부분 일치 < 중간 일치 < 초성/입력 중 < 오타). 맞지 않으면 false
+func suggestScore(text, query []rune) (float64, bool) {
+	switch idx := strings.Index(string(text), string(query)); {
+	case idx == 0:
+		return 0, true
+	case idx > 0:
+		return 1, true
+	}
+	switch idx := hangulIndex(text, query); {
+	case idx == 0:
+		return 0.5, true
+	case idx > 0:
+		return 1.5, true
+	}
+	if isChosungQuery(string(query)) {
+		return 0, false
+	}
+	if d := typoDistance(text, query); d <= maxTypos(query) {
+		return 3 + float64(d), true
+	}
+	return 0, false
+}
+
+// Suggest: 입력 중인 검색어로 식당 이름, 카테고리, 메뉴 이름 추천
+func Suggest(db *gorm.DB, q string, limit int) ([]Suggestion, error) {
+	query := compactRunes(q)
+	if len(query) == 0 {
+		return []Suggestion{}, nil
+	}
+
+	cands, err := loadSearchCandidates(db)
+	if err != nil {
+		return nil, err
+	}
+
+	type candidate struct {
+		Suggestion
+		forms [][]rune // 비교용 표기 (compactForms)
+	}
+	candidates := make([]candidate, 0, len(cands.restaurants)+len(cands.categories)+len(cands.menus))
+	for _, res := range cands.restaurants {
+		candidates = append(candidates, candidate{Suggestion{Type: "restaurant", Text: res.Title, RestaurantID: res.ID}, res.title})
+	}
+	for _, cat := range cands.categories {
+		candidates = append(candidates, candidate{Suggestion{Type: "category", Text: cat.Name, score: 0.1}, cat.name})
+	}
+	for _, m := range cands.menus {
+		candidates = append(candidates, candidate{Suggestion{Type: "menu", Text: m.Name, RestaurantID: m.RestaurantID, score: 0.2}, m.name})
+	}
+
+	seen := map[string]bool{}
+	results := []Suggestion{}
+	for _, cand := range candidates {
+		key := cand.Type + ":" + cand.Text
+		if seen[key] {
+			continue // 같은 이름의 메뉴는 하나만
+		}
+		score, ok := 0.0, false
+		for _, text := range cand.forms {
+			if s, found := suggestScore(text, query); found && (!ok || s < score) {
+				score, ok = s, true
+			}
+		}
+		if !ok {
+			continue
+		}
+		seen[key] = true
+		sug := cand.Suggestion
+		sug.score += score
+		sug.Highlight, _ = highlight(sug.Text, []string{string(query)})
+		if sug.Highlight == "" {
+			sug.Highlight = html.EscapeString(sug.Text)
+		}
+		results = append(results, sug)
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].score != results[j].score {
+			return results[i].score < results[j].score
+		}
+		return utf8.RuneCountInString(results[i].Text) < utf8.RuneCountInString(results[j].Text)
+	})
+	if len(results) > limit {
+		results = results[:limit]
+	}
+	return results, nil
+}
+
+// initSearchIndex: 검색 색인 테이블을 만들고 전체를 다시 색인 (식당 수가 적어 시작할 때마다 새로 만든다)
+func initSearchIndex(db *gorm.DB) {
+	if err := db.Exec(createSearchTable).Error; err != nil {
+		log.Println("ERROR 검색 색인 생성 실패:", err)
+		return
+	}
+	if err := rebuildSearchIndex(db); err != nil {
+		log.Println("ERROR 검색 색인 갱신 실패:", err)
+	}
+	invalidateSearchCandidates()
+}
+
+// rebuildSearchIndex: 삭제되지 않은 모든 식당을 다시 색인
+func rebuildSearchIndex(db *gorm.DB) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("DELETE FROM restaurant_search").Error; err != nil {
+			return err
+		}
+		var ids []uint
+		if err := tx.Model(&Restaurant{}).Pluck("id", &ids).Error; err != nil {
+			return err
+		}
+		for _, id := range ids {
+			if err := reindexRestaurant(tx, id); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// reindexRestaurant: 식당 하나의 색인을 갱신 (삭제된 식당은 색인에서 뺌)
+func reindexRestaurant(db *gorm.DB, restaurantID uint) error {
+	if err := db.Exec("DELETE FROM restaurant_search WHERE rowid = ?", restaurantID).Error; err != nil {
+		return err
+	}
+	var res Restaurant
+	if err := db.Limit(1).Find(&res, restaurantID).Error; err != nil || res.ID == 0 {
+		return err
+	}
+	menu, err := menuText(db, restaurantID)
+	if err != nil {
+		return err
+	}
+	return db.Exec("INSERT INTO restaurant_search (rowid, title, food, addr, menu) VALUES (?, ?, ?, ?, ?)",
+		res.ID, searchTokens(res.Title), searchTokens(res.Food), searchTokens(res.Addr), searchTokens(menu)).Error
+}
+
+// menuText: 식당 메뉴 이름을 쉼표로 이은 문자열
+func menuText(db *gorm.DB, restaurantID uint) (string, error) {
+	texts, err := menuTexts(db, []uint{restaurantID})
+	return texts[restaurantID], err
+}
+
+// menuTexts: 여러 식당의 menuText를 한 번에 조회 (식당 ID → 문자열)
+func menuTexts(db *gorm.DB, restaurantIDs []uint) (map[uint]string, error) {
+	var items []MenuItem
+	err := db.Select("restaurant_id", "name").Where("restaurant_id IN ?", restaurantIDs).
+		Order("signature DESC, price ASC, id ASC").Find(&items).Error
+	if err != nil {
+		return nil, err
+	}
+	names := map[uint][]string{}
+	for _, item := range items {
+		names[item.RestaurantID] = append(names[item.RestaurantID], item.Name)
+	}
+	texts := make(map[uint]string, len(names))
+	for id, list := range names {
+		texts[id] = strings.Join(list, ", ")
+	}
+	return texts, nil
+}
+
+// AttachHighlights: 검색 결과에 일치 부분을 <mark>로 감싼 필드별 스니펫을 붙임
+func AttachHighlights(db *gorm.DB, results []RestaurantResult, search string) error {
+	words := searchWords(search)
+	if len(words) == 0 || len(results) == 0 {
+		return nil
+	}
+	ids := make([]uint, len(results))
+	for i, res := range results {
+		ids[i] = res.ID
+	}
+	menus, err := menuTexts(db, ids)
+	if err != nil {
+		return err
+	}
+	for i := range results {
+		res := &results[i]
+		fields := map[string]string{"title": res.Title, "food": res.Food, "addr": res.Addr, "menu": menus[res.ID]}
+		for name, text := range fields {
+			if snippet, ok := highlight(text, words); ok {
+				if res.Highlights == nil {
+					res.Highlights = map[string]string{}
+				}
+				res.Highlights[name] = snippet
+			}
+		}
+	}
+	return nil
+}
+
+// highlight: text에서 검색어 단어와 맞는 부분(초성, 입력 중 글자, 작은 오타 포함)을 <mark>로 감싸고
+// 첫 일치 부분 주변만 잘라냄 (HTML 이스케이프 포함)
+func highlight(text string, words []string) (string, bool) {
+	runes := []rune(text)
+	plain := []rune(strings.ToLower(text))
+	if len(plain) != len(runes) {
+		plain = runes // 대소문자 변환으로 길이가 바뀌는 드문 경우
+	}
+	lower := []rune(normalizeSpelling(string(plain)))
+	// 색인처럼 원래 표기로도 비교 ("돈까"는 "돈까스"의 앞부분)
+	forms := [][]rune{lower}
+	if string(plain) != string(lower) {
+		forms = append(forms, plain)
+	}
+
+	marked := make([]bool, len(runes))
+	first := -1
+	mark := func(from, to int) {
+		for j := from; j < to; j++ {
+			marked[j] = true
+		}
+		if first == -1 || from < first {
+			first = from
+		}
+	}
+	for _, word := range words {
+		w := []rune(word)
+		found := false
+		for _, form := range forms {
+			for i := 0; i+len(w) <= len(form); i++ {
+				if hangulIndex(form[i:i+len(w)], w) == 0 {
+					mark(i, i+len(w))
+					found = true
+				}
+			}
+		}
+		if found || isChosungQuery(word) || maxTypos(w) == 0 {
+			continue
+		}
+		// 오타로 맞은 경우 가장 가까운 구간 표시
+		if from, to, ok := typoWindow(lower, w); ok {
+			mark(from, to)
+		}
+	}
+	if first == -1 {
+		return "", false
+	}
+
+	start, end := max(0, first-snippetRadius), min(len(runes), first+snippetRadius*2)
+	var b strings.Builder
+	if start > 0 {
+		b.WriteString("…")
+	}
+	for i := start; i < end; i++ {
+		if marked[i] && (i == start || !marked[i-1]) {
+			b.WriteString("<mark>")
+		}
+		b.WriteString(html.EscapeString(string(runes[i])))
+		if marked[i] && (i == end-1 || !marked[i+1]) {
+			b.WriteString("</mark>")
+		}
+	}
+	if end < len(runes) {
+		b.WriteString("…")
+	}
+	return b.String(), true
+}
+
+// typoWindow: 허용 오타 수 안에서 word와 가장 가까운 text 구간 (거리가 같으면 word와 같은 길이 우선)
+func typoWindow(text, word []rune) (int, int, bool) {
+	best, from, to := maxTypos(word)+1, 0, 0
+	for _, size := range []int{len(word), len(word) - 1, len(word) + 1} {
+		for i := 0; size > 0 && i+size <= len(text); i++ {
+			if d := editDistance(text[i:i+size], word); d < best {
+				best, from, to = d, i, i+size
+			}
+		}
+	}
+	return from, to, best <= maxTypos(word)
+}
@@ -0,0 +1,119 @@
+package store
+
+import (
+	"database/sql"
+	"sync"
+
+	"gorm.io/gorm"
+)
+
+// searchCandidates: 초성/오타 허용 검색과 자동완성의 비교 대상 (삭제되지 않은 식당, 카테고리, 메뉴 이름).
+// 요청마다 테이블 전체를 읽지 않도록 DB별로 캐시하고, 비교용 글자도 미리 만들어 둔다.
+type searchCandidates struct {
+	restaurants []candidateRestaurant
+	categories  []candidateCategory
+	menus       []candidateMenu
+}
+
+type candidateRestaurant struct {
+	ID          uint
+	Title       string
+	title, food [][]rune // compactForms
+}
+
+type candidateCategory struct {
+	Name string
+	name [][]rune
+}
+
+type candidateMenu struct {
+	RestaurantID uint
+	Name         string
+	name         [][]rune
+}
+
+// searchCache: DB별 검색 후보. 식당/메뉴를 바꾸는 트랜잭션(indexTransaction)이 끝나면 비운다.
+// gen은 비울 때마다 올라가며, 읽는 동안 비워졌으면 읽은 값을 캐시하지 않는다.
+var searchCache = struct {
+	sync.Mutex
+	gen     uint64
+	entries map[*sql.DB]*searchCandidates
+}{entries: map[*sql.DB]*searchCandidates{}}
+
+// invalidateSearchCandidates: 검색 후보 캐시를 비움
+func invalidateSearchCandidates() {
+	searchCache.Lock()
+	searchCache.gen++
+	clear(searchCache.entries)
+	searchCache.Unlock()
+}
+
+// indexTransaction: 검색 색인을 함께 고치는 트랜잭션. 커밋 또는 롤백 후 검색 후보 캐시를 비운다
+func indexTransaction(db *gorm.DB, fn func(tx *gorm.DB) error) error {
+	defer invalidateSearchCandidates()
+	return db.Transaction(fn)
+}
+
+// loadSearchCandidates: 캐시된 검색 후보 (없으면 DB에서 읽어 캐시).
+// 트랜잭션 안에서는 커밋되지 않은 변경이 보일 수 있으므로 캐시를 쓰지도 채우지도 않는다
+// (트랜잭션의 db.DB()도 같은 *sql.DB를 돌려주므로 ConnPool로 구분)
+func loadSearchCandidates(db *gorm.DB) (*searchCandidates, error) {
+	if _, inTx := db.Statement.ConnPool.(*sql.Tx); inTx {
+		return querySearchCandidates(db)
+	}
+	key, err := db.DB()
+	if err != nil {
+		return querySearchCandidates(db)
+	}
+
+	searchCache.Lock()
+	cached, gen := searchCache.entries[key], searchCache.gen
+	searchCache.Unlock()
+	if cached != nil {
+		return cached, nil
+	}
+
+	cands, err := querySearchCandidates(db)
+	if err != nil {
+		return nil, err
+	}
+	searchCache.Lock()
+	if searchCache.gen == gen {
+		searchCache.entries[key] = cands
+	}
+	searchCache.Unlock()
+	return cands, nil
+}
+
+// querySearchCandidates: DB에서 검색 후보를 읽음
+func querySearchCandidates(db *gorm.DB) (*searchCandidates, error) {
+	var list []Restaurant
+	if err := db.Select("id", "title", "food").Find(&list).Error; err != nil {
+		return nil, err
+	}
+	var categories []Category
+	if err := db.Find(&categories).Error; err != nil {
+		return nil, err
+	}
+	var menus []MenuItem
+	if err := db.Joins("JOIN restaurants ON restaurants.id = menu_items.restaurant_id AND restaurants.deleted_at IS NULL").
+		Select("menu_items.restaurant_id", "menu_items.name").Find(&menus).Error; err != nil {
+		return nil, err
+	}
+
+	cands := &searchCandidates{
+		restaurants: make([]candidateRestaurant, len(list)),
+		categories:  make([]candidateCategory, len(categories)),
+		menus:       make([]candidateMenu, len(menus)),
+	}
+	for i, res := range list {
+		cands.restaurants[i] = candidateRestaurant{ID: res.ID, Title: res.Title, title: compactForms(res.Title), food: compactForms(res.Food)}
+	}
+	for i, cat := range categories {
+		cands.categories[i] = candidateCategory{Name: cat.Name, name: compactForms(cat.Name)}
+	}
+	for i, m := range menus {
+		cands.menus[i] = candidateMenu{RestaurantID: m.RestaurantID, Name: m.Name, name: compactForms(m.Name)}
+	}
+	return cands, nil
+}
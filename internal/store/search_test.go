@@ -0,0 +1,84 @@
+package store
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"gorm.io/gorm"
+)
+
+func TestSearchSpellingVariants(t *testing.T) {
+	db := newTestDB(t)
+	addRestaurant(t, db, 1, "돈까스 천국", "돈까스")
+	addRestaurant(t, db, 2, "남촌김밥", "분식")
+
+	tests := []struct {
+		search string
+		want   string // 하이라이트에 들어가야 할 부분
+	}{
+		{"돈까", "<mark>돈까</mark>스 천국"},  // 원래 표기의 앞부분
+		{"돈가스", "<mark>돈까스</mark> 천국"}, // 표준 표기
+		{"돈까스", "<mark>돈까스</mark> 천국"}, // 원래 표기 (표준 표기로 바뀐 검색어)
+		{"돈까스 천국", "<mark>돈까스</mark> <mark>천국</mark>"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.search, func(t *testing.T) {
+			list, total, err := ListRestaurants(db, RestaurantFilter{Search: tt.search}, "", 20, 0)
+			if err == nil {
+				err = AttachHighlights(db, list, tt.search)
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if total != 1 || list[0].Title != "돈까스 천국" {
+				t.Fatalf("results = %d %+v", total, list)
+			}
+			if got := list[0].Highlights["title"]; got != tt.want {
+				t.Errorf("highlight = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	for _, q := range []string{"돈까", "돈가스"} {
+		list, err := Suggest(db, q, 5)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(list) == 0 || !strings.Contains(list[0].Text, "돈까스") {
+			t.Errorf("suggest %q = %+v", q, list)
+		}
+	}
+}
+
+func TestSearchCandidatesNotCachedInTransaction(t *testing.T) {
+	db := newTestDB(t)
+	addRestaurant(t, db, 1, "남촌김밥", "분식")
+
+	// 트랜잭션 안에서 초성/오타 허용 검색으로 아직 커밋되지 않은 식당을 읽은 뒤 롤백
+	errRollback := errors.New("rollback")
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&Restaurant{Title: "새 식당", Food: "카페"}).Error; err != nil {
+			return err
+		}
+		hits, err := fuzzyHits(tx, "새식당")
+		if err != nil {
+			return err
+		}
+		if len(hits) != 1 {
+			t.Errorf("hits in transaction = %+v", hits)
+		}
+		return errRollback
+	})
+	if !errors.Is(err, errRollback) {
+		t.Fatal(err)
+	}
+
+	hits, err := fuzzyHits(db, "새식당")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(hits) != 0 {
+		t.Fatalf("rolled-back restaurant is still a candidate: %+v", hits)
+	}
+}
@@ -0,0 +1,235 @@
+package store
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// 기본 맛집 데이터. 내용을 바꾸면 version을 올려야 기존 배포에도 반영된다.
+//
+//go:embed seed/restaurants.json
+var seedJSON []byte
+
+const seedName = "restaurants"
+
+// SeedFile: seed/restaurants.json 형식
+type SeedFile struct {
+	Version     int              `json:"version"`
+	Restaurants []SeedRestaurant `json:"restaurants"`
+}
+
+// SeedRestaurant: 카카오 장소 ID(place_id)로 식별하는 시드 항목 (모르면 비워 두고 이름으로 식별)
+type SeedRestaurant struct {
+	PlaceID string  `json:"place_id"`
+	Title   string  `json:"title"`
+	Addr    string  `json:"addr"`
+	Food    string  `json:"food"`
+	X       float64 `json:"x"`
+	Y       float64 `json:"y"`
+	URL     string  `json:"url"`
+}
+
+// 적용된 시드 버전 기록
+type SeedVersion struct {
+	Name      string `gorm:"primaryKey"`
+	Version   int
+	AppliedAt time.Time
+}
+
+// SeedChange: 기존 식당과 시드 항목의 차이
+type SeedChange struct {
+	Restaurant Restaurant
+	Seed       SeedRestaurant
+	Fields     []string // 바뀌는 필드 이름
+}
+
+// SeedPlan: 시드 적용 시 추가/변경/삭제될 식당 목록
+type SeedPlan struct {
+	Added    []SeedRestaurant
+	Changed  []SeedChange
+	Removed  []Restaurant // prune 옵션일 때만 실제로 삭제
+	Warnings []string
+}
+
+// LoadSeedFile: 내장된 시드 파일 읽기
+func LoadSeedFile() (SeedFile, error) {
+	var file SeedFile
+	if err := json.Unmarshal(seedJSON, &file); err != nil {
+		return file, fmt.Errorf("seed: %w", err)
+	}
+	return file, nil
+}
+
+// PlanSeed: DB와 시드 파일을 비교해 적용 계획을 만듦.
+// 장소 ID로 짝짓고, 장소 ID가 없거나 여러 항목이 함께 쓰는 항목은 장소 ID가 비어 있는 식당 중 이름이 같은 것과 짝짓는다.
+func PlanSeed(db *gorm.DB, file SeedFile) (SeedPlan, error) {
+	var plan SeedPlan
+
+	// 관리자가 삭제한 식당을 다시 추가하지 않도록 삭제된 식당도 비교 대상에 포함
+	var existing []Restaurant
+	if err := db.Unscoped().Order("id").Find(&existing).Error; err != nil {
+		return plan, err
+	}
+	byPlace := map[string]*Restaurant{}
+	for i := range existing {
+		if existing[i].PlaceID != nil {
+			byPlace[*existing[i].PlaceID] = &existing[i]
+		}
+	}
+
+	// 여러 항목이 같은 장소 ID를 쓰면 어느 쪽이 맞는지 알 수 없으므로 모두 비우고 이름으로 짝짓는다
+	// (migratePlaceIDs와 같은 규칙, data-quality가 같은 URL로 찾아 줌)
+	placeCount := map[string]int{}
+	for _, seed := range file.Restaurants {
+		if seed.PlaceID != "" {
+			placeCount[seed.PlaceID]++
+		}
+	}
+	matched := map[uint]bool{}
+	for _, seed := range file.Restaurants {
+		if placeCount[seed.PlaceID] > 1 {
+			plan.Warnings = append(plan.Warnings, fmt.Sprintf("장소 ID 중복이라 비워 둠: %s (%s)", seed.PlaceID, seed.Title))
+			seed.PlaceID = ""
+		}
+
+		res := byPlace[seed.PlaceID]
+		if res == nil {
+			res = matchSeedTitle(existing, seed, matched, placeCount)
+		}
+		if res == nil {
+			plan.Added = append(plan.Added, seed)
+			continue
+		}
+		matched[res.ID] = true
+		if res.DeletedAt.Valid {
+			plan.Warnings = append(plan.Warnings, fmt.Sprintf("삭제된 식당이라 건너뜀: [%d] %s", res.ID, res.Title))
+			continue
+		}
+		if fields := seedDiff(*res, seed); len(fields) > 0 {
+			plan.Changed = append(plan.Changed, SeedChange{Restaurant: *res, Seed: seed, Fields: fields})
+		}
+	}
+
+	for _, res := range existing {
+		if !matched[res.ID] && !res.DeletedAt.Valid {
+			plan.Removed = append(plan.Removed, res)
+		}
+	}
+	return plan, nil
+}
+
+// matchSeedTitle: 아직 짝지어지지 않은 식당 중 이름이 같은 식당. 장소 ID가 비어 있거나,
+// 시드 파일에서 한 항목만 쓰는 장소 ID가 아닌 식당만 (이전에 저장된 중복 ID는 시드에 맞춰 비운다)
+func matchSeedTitle(existing []Restaurant, seed SeedRestaurant, matched map[uint]bool, placeCount map[string]int) *Restaurant {
+	for i := range existing {
+		res := &existing[i]
+		if (res.PlaceID == nil || placeCount[*res.PlaceID] != 1) && !matched[res.ID] && res.Title == seed.Title {
+			return res
+		}
+	}
+	return nil
+}
+
+// seedDiff: 시드 항목과 값이 다른 필드 이름
+func seedDiff(res Restaurant, seed SeedRestaurant) []string {
+	var fields []string
+	if (res.PlaceID == nil && seed.PlaceID != "") || (res.PlaceID != nil && *res.PlaceID != seed.PlaceID) {
+		fields = append(fields, "place_id")
+	}
+	if res.Title != seed.Title {
+		fields = append(fields, "title")
+	}
+	if res.Addr != seed.Addr {
+		fields = append(fields, "addr")
+	}
+	if res.Food != seed.Food {
+		fields = append(fields, "food")
+	}
+	if res.X != seed.X || res.Y != seed.Y {
+		fields = append(fields, "x/y")
+	}
+	if res.URL != seed.URL {
+		fields = append(fields, "url")
+	}
+	return fields
+}
+
+// ApplySeed: 계획대로 추가/변경 (prune이면 시드에 없는 식당은 소프트 삭제)
+func ApplySeed(db *gorm.DB, plan SeedPlan, prune bool) error {
+	return indexTransaction(db, func(tx *gorm.DB) error {
+		for _, seed := range plan.Added {
+			res := Restaurant{Title: seed.Title, Addr: seed.Addr, Food: seed.Food, X: seed.X, Y: seed.Y, URL: seed.URL}
+			res.PlaceID = placeIDPtr(seed.PlaceID)
+			if err := tx.Create(&res).Error; err != nil {
+				return err
+			}
+			if err := setRestaurantCategories(tx, res.ID, res.Food); err != nil {
+				return err
+			}
+		}
+		for _, change := range plan.Changed {
+			seed := change.Seed
+			if err := tx.Model(&Restaurant{}).Where("id = ?", change.Restaurant.ID).Updates(map[string]any{
+				"title": seed.Title, "addr": seed.Addr, "food": seed.Food, "x": seed.X, "y": seed.Y, "url": seed.URL,
+				"place_id": placeIDPtr(seed.PlaceID),
+			}).Error; err != nil {
+				return err
+			}
+			if err := setRestaurantCategories(tx, change.Restaurant.ID, seed.Food); err != nil {
+				return err
+			}
+		}
+		if prune {
+			for _, res := range plan.Removed {
+				if err := tx.Delete(&Restaurant{}, res.ID).Error; err != nil {
+					return err
+				}
+			}
+		}
+		return rebuildSearchIndex(tx)
+	})
+}
+
+// SeedOnStartup: 시드 파일 버전이 올라갔을 때만 추가/변경을 적용 (삭제는 하지 않음).
+// 버전이 같으면 관리자 API로 고친 내용을 덮어쓰지 않는다.
+func SeedOnStartup(db *gorm.DB) {
+	file, err := LoadSeedFile()
+	if err != nil {
+		log.Println("ERROR 시드 파일 읽기 실패:", err)
+		return
+	}
+
+	var applied SeedVersion
+	db.Where("name = ?", seedName).Limit(1).Find(&applied)
+	if applied.Version >= file.Version {
+		return
+	}
+
+	plan, err := PlanSeed(db, file)
+	if err != nil {
+		log.Println("ERROR 시드 비교 실패:", err)
+		return
+	}
+	for _, w := range plan.Warnings {
+		log.Println("WARN  시드:", w)
+	}
+	if err := ApplySeed(db, plan, false); err != nil {
+		log.Println("ERROR 시드 적용 실패:", err)
+		return
+	}
+	if err := RecordSeedVersion(db, file.Version); err != nil {
+		log.Println("ERROR 시드 버전 기록 실패:", err)
+		return
+	}
+	log.Printf("INFO  시드 v%d 적용: 추가 %d, 변경 %d\n", file.Version, len(plan.Added), len(plan.Changed))
+}
+
+// RecordSeedVersion: 적용한 시드 버전 저장
+func RecordSeedVersion(db *gorm.DB, version int) error {
+	return db.Save(&SeedVersion{Name: seedName, Version: version, AppliedAt: time.Now()}).Error
+}
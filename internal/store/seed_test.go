@@ -0,0 +1,154 @@
+package store
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// seedEntry: 이름과 장소 ID만 다른 시드 항목 (URL은 장소 ID로 만듦)
+func seedEntry(title, placeID string) SeedRestaurant {
+	return SeedRestaurant{
+		PlaceID: placeID, Title: title, Addr: "경기 안양시 만안구 성결대학로 1", Food: "한식",
+		X: 126.93, Y: 37.38, URL: "https://place.map.kakao.com/" + placeID,
+	}
+}
+
+func TestPlanSeed(t *testing.T) {
+	type existing struct {
+		seed    SeedRestaurant
+		deleted bool
+	}
+	moved := seedEntry("가야밀면", "")
+	moved.Addr = "경기 안양시 만안구 성결대학로 9"
+
+	tests := []struct {
+		name     string
+		existing []existing
+		seed     []SeedRestaurant
+		added    []string
+		changed  []string // "이름: 필드,필드"
+		removed  []string
+		warnings int
+	}{
+		{
+			name:  "빈 DB",
+			seed:  []SeedRestaurant{seedEntry("가야밀면", "100"), seedEntry("남촌김밥", "")},
+			added: []string{"가야밀면", "남촌김밥"},
+		},
+		{
+			name:     "같은 내용",
+			existing: []existing{{seed: seedEntry("가야밀면", "100")}},
+			seed:     []SeedRestaurant{seedEntry("가야밀면", "100")},
+		},
+		{
+			name:     "장소 ID로 짝지어 이름 변경",
+			existing: []existing{{seed: seedEntry("가야밀면", "100")}},
+			seed:     []SeedRestaurant{seedEntry("가야 밀면", "100")},
+			changed:  []string{"가야밀면: title"},
+		},
+		{
+			name:     "장소 ID가 없으면 이름으로 짝지음",
+			existing: []existing{{seed: seedEntry("가야밀면", "")}},
+			seed:     []SeedRestaurant{moved},
+			changed:  []string{"가야밀면: addr"},
+		},
+		{
+			name:     "장소 ID가 생김",
+			existing: []existing{{seed: seedEntry("가야밀면", "")}},
+			seed:     []SeedRestaurant{seedEntry("가야밀면", "100")},
+			changed:  []string{"가야밀면: place_id,url"},
+		},
+		{
+			name:     "시드 안에서 겹치는 장소 ID는 모두 비움",
+			seed:     []SeedRestaurant{seedEntry("더카페", "200"), seedEntry("하이포커스", "200")},
+			added:    []string{"더카페", "하이포커스"},
+			warnings: 2,
+		},
+		{
+			name:     "이전에 저장된 겹치는 장소 ID도 비움",
+			existing: []existing{{seed: seedEntry("더카페", "200")}},
+			seed:     []SeedRestaurant{seedEntry("더카페", "200"), seedEntry("하이포커스", "200")},
+			added:    []string{"하이포커스"},
+			changed:  []string{"더카페: place_id"},
+			warnings: 2,
+		},
+		{
+			name:     "삭제된 식당은 다시 추가하지 않음",
+			existing: []existing{{seed: seedEntry("가야밀면", "100"), deleted: true}},
+			seed:     []SeedRestaurant{seedEntry("가야밀면", "100")},
+			warnings: 1,
+		},
+		{
+			name:     "시드에 없는 식당",
+			existing: []existing{{seed: seedEntry("가야밀면", "100")}, {seed: seedEntry("폐업 식당", "300")}, {seed: seedEntry("삭제된 식당", "400"), deleted: true}},
+			seed:     []SeedRestaurant{seedEntry("가야밀면", "100")},
+			removed:  []string{"폐업 식당"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := newTestDB(t)
+			for _, e := range tt.existing {
+				s := e.seed
+				res := Restaurant{Title: s.Title, Addr: s.Addr, Food: s.Food, X: s.X, Y: s.Y, URL: s.URL, PlaceID: placeIDPtr(s.PlaceID)}
+				if err := db.Create(&res).Error; err != nil {
+					t.Fatal(err)
+				}
+				if e.deleted {
+					if err := db.Delete(&res).Error; err != nil {
+						t.Fatal(err)
+					}
+				}
+			}
+
+			plan, err := PlanSeed(db, SeedFile{Version: 1, Restaurants: tt.seed})
+			if err != nil {
+				t.Fatal(err)
+			}
+			var added, changed, removed []string
+			for _, s := range plan.Added {
+				added = append(added, s.Title)
+			}
+			for _, c := range plan.Changed {
+				changed = append(changed, fmt.Sprintf("%s: %s", c.Restaurant.Title, strings.Join(c.Fields, ",")))
+			}
+			for _, res := range plan.Removed {
+				removed = append(removed, res.Title)
+			}
+			if !slices.Equal(added, tt.added) {
+				t.Errorf("added = %v, want %v", added, tt.added)
+			}
+			if !slices.Equal(changed, tt.changed) {
+				t.Errorf("changed = %v, want %v", changed, tt.changed)
+			}
+			if !slices.Equal(removed, tt.removed) {
+				t.Errorf("removed = %v, want %v", removed, tt.removed)
+			}
+			if len(plan.Warnings) != tt.warnings {
+				t.Errorf("warnings = %q, want %d", plan.Warnings, tt.warnings)
+			}
+			for _, s := range plan.Added {
+				if tt.warnings > 0 && s.PlaceID != "" {
+					t.Errorf("added %s keeps duplicate place ID %s", s.Title, s.PlaceID)
+				}
+			}
+		})
+	}
+}
+
+// 내장 시드 파일은 겹치는 장소 ID 없이 그대로 적용되어야 한다
+func TestEmbeddedSeedFile(t *testing.T) {
+	file, err := LoadSeedFile()
+	if err != nil {
+		t.Fatal(err)
+	}
+	plan, err := PlanSeed(newTestDB(t), file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(plan.Added) != len(file.Restaurants) || len(plan.Warnings) != 0 {
+		t.Errorf("plan = %d added, warnings %q", len(plan.Added), plan.Warnings)
+	}
+}
@@ -0,0 +1,41 @@
+package store
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// 서버 측 세션 저장 테이블
+type SessionRecord struct {
+	ID        string    `gorm:"primaryKey;size:64"`
+	UserID    *uint     `gorm:"index"` // 로그인 사용자 (강제 로그아웃용)
+	Data      string    // securecookie로 서명/암호화한 세션 값
+	ExpiresAt time.Time `gorm:"index"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// RevokeUserSessions: 사용자의 모든 서버 측 세션 삭제 (강제 로그아웃)
+func RevokeUserSessions(db *gorm.DB, userID uint) (int64, error) {
+	result := db.Where("user_id = ?", userID).Delete(&SessionRecord{})
+	return result.RowsAffected, result.Error
+}
+
+// CleanupExpiredSessions: 만료된 세션을 주기적으로 삭제 (ctx가 끝나면 반환)
+func CleanupExpiredSessions(ctx context.Context, db *gorm.DB, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if err := db.WithContext(ctx).Where("expires_at <= ?", time.Now()).Delete(&SessionRecord{}).Error; err != nil && ctx.Err() == nil {
+			log.Println("ERROR 만료 세션 정리 실패:", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,46 @@
+package store
+
+import (
+	"fmt"
+	"testing"
+
+	"gorm.io/gorm"
+)
+
+// newTestDB: 테스트마다 새로 만드는 메모리 DB
+func newTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := Open(":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if sqlDB, err := db.DB(); err == nil {
+			sqlDB.Close()
+		}
+	})
+	return db
+}
+
+// addRestaurant: 이름과 음식 종류만 정한 식당 추가 (장소 ID는 n으로 구분)
+func addRestaurant(t *testing.T, db *gorm.DB, n int, title, food string) Restaurant {
+	t.Helper()
+	res, err := CreateRestaurant(db, RestaurantInput{
+		Title: title, Addr: fmt.Sprintf("경기 안양시 만안구 성결대학로 %d", n), Food: food,
+		X: 126.93, Y: 37.38, URL: fmt.Sprintf("https://place.map.kakao.com/%d", n),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return res
+}
+
+// addUser: 카카오 ID로 구분하는 사용자 추가
+func addUser(t *testing.T, db *gorm.DB, kakaoID int64) User {
+	t.Helper()
+	user, err := UpsertKakaoUser(db, kakaoID, fmt.Sprintf("사용자%d", kakaoID))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return user
+}
@@ -0,0 +1,92 @@
+package store
+
+import (
+	"errors"
+	"log"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var ErrUserNotFound = errors.New("user not found")
+
+// UpsertKakaoUser: 카카오 ID 기준으로 사용자를 생성하거나 닉네임을 갱신
+func UpsertKakaoUser(db *gorm.DB, kakaoID int64, nickname string) (User, error) {
+	user := User{KakaoID: kakaoID, Nickname: nickname}
+	if err := db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "kakao_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"nickname", "updated_at"}),
+	}).Create(&user).Error; err != nil {
+		return user, err
+	}
+	// ON CONFLICT 갱신 시에는 ID가 채워지지 않으므로 다시 조회
+	err := db.Where("kakao_id = ?", kakaoID).First(&user).Error
+	return user, err
+}
+
+// migrateLegacyRatings: 닉네임을 user_id로 저장하던 ratings 테이블을 전환.
+// 기존 값은 legacy_user_name 컬럼으로 옮기고, user_id는 users.id를 가리키게 된다.
+func migrateLegacyRatings(db *gorm.DB) {
+	if !db.Migrator().HasTable(&Rating{}) || db.Migrator().HasColumn(&Rating{}, "legacy_user_name") {
+		return
+	}
+	columns, err := db.Migrator().ColumnTypes(&Rating{})
+	if err != nil {
+		log.Println("ERROR ratings 컬럼 조회 실패:", err)
+		return
+	}
+	for _, col := range columns {
+		if col.Name() != "user_id" {
+			continue
+		}
+		if dbType := col.DatabaseTypeName(); dbType != "text" && dbType != "TEXT" {
+			return
+		}
+	}
+
+	err = db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("DROP INDEX IF EXISTS idx_rating_restaurant_user").Error; err != nil {
+			return err
+		}
+		if err := tx.Exec("ALTER TABLE ratings RENAME COLUMN user_id TO legacy_user_name").Error; err != nil {
+			return err
+		}
+		return tx.Exec("ALTER TABLE ratings ADD COLUMN user_id integer").Error
+	})
+	if err != nil {
+		log.Println("ERROR 닉네임 기반 별점 전환 실패:", err)
+		return
+	}
+	log.Println("INFO  닉네임 기반 별점을 legacy_user_name 컬럼으로 전환")
+}
+
+// ClaimLegacyRatings: 닉네임으로 남겨진 이전 별점을 사용자에게 연결 (관리자 명령 claim-ratings 전용).
+// 닉네임은 누구나 같은 값을 쓸 수 있으므로 로그인 시 자동으로 연결하지 않는다.
+// 사용자가 이미 별점을 남긴 식당의 이전 별점은 지우지 않고 주인 없는 채로 둔다.
+// 주인 없는 별점도 평균에 포함되어 있으므로 연결만으로는 집계가 바뀌지 않는다.
+func ClaimLegacyRatings(db *gorm.DB, legacyName string, userID uint) (claimed, skipped int64, err error) {
+	if !db.Migrator().HasColumn(&Rating{}, "legacy_user_name") {
+		return 0, 0, nil
+	}
+	err = db.Transaction(func(tx *gorm.DB) error {
+		found := tx.Limit(1).Find(&User{}, userID)
+		if found.Error != nil {
+			return found.Error
+		}
+		if found.RowsAffected == 0 {
+			return ErrUserNotFound
+		}
+		result := tx.Exec(`
+UPDATE ratings SET user_id = ?, legacy_user_name = NULL
+WHERE user_id IS NULL AND legacy_user_name = ?
+	AND restaurant_id NOT IN (SELECT restaurant_id FROM ratings WHERE user_id = ?)`,
+			userID, legacyName, userID)
+		if result.Error != nil {
+			return result.Error
+		}
+		claimed = result.RowsAffected
+		return tx.Raw("SELECT COUNT(*) FROM ratings WHERE user_id IS NULL AND legacy_user_name = ?", legacyName).
+			Scan(&skipped).Error
+	})
+	return claimed, skipped, err
+}